@@ -21,11 +21,17 @@ func (t *Ticket) IsExpired() bool {
 	return time.Now().Add(5 * time.Minute).After(t.Expiration)
 }
 
-const ticketFile = "ticket.json"
+// DefaultTicketFile is the file used by Save, CanLoadTicket and LoadTicket.
+const DefaultTicketFile = "ticket.json"
 
-// Save serializes this ticket to a file which can be loaded by Load().
+// Save serializes this ticket to DefaultTicketFile which can be loaded by LoadTicket().
 // This is a means of caching, attempting to minimize authentication overhead for every request to the API.
-func (t *Ticket) Save() (err error) {
+func (t *Ticket) Save() error {
+	return t.SaveTo(DefaultTicketFile)
+}
+
+// SaveTo performs the same as Save, but uses the file at path.
+func (t *Ticket) SaveTo(path string) (err error) {
 	var data []byte
 	data, err = json.Marshal(t)
 	if err != nil {
@@ -33,7 +39,7 @@ func (t *Ticket) Save() (err error) {
 	}
 
 	var file *os.File
-	file, err = os.OpenFile(ticketFile, os.O_WRONLY|os.O_CREATE, 0666)
+	file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
 	if err != nil {
 		return
 	}
@@ -49,7 +55,12 @@ func (t *Ticket) Save() (err error) {
 // CanLoadTicket returns a boolean indicating if a cached ticket is present and can be loaded.
 // Returns an error if an unexpected error occurs.
 func CanLoadTicket() (ok bool, err error) {
-	_, statErr := os.Stat(ticketFile)
+	return CanLoadTicketFrom(DefaultTicketFile)
+}
+
+// CanLoadTicketFrom performs the same as CanLoadTicket, but uses the file at path.
+func CanLoadTicketFrom(path string) (ok bool, err error) {
+	_, statErr := os.Stat(path)
 	if statErr != nil {
 		if os.IsNotExist(statErr) {
 			ok = false
@@ -64,9 +75,14 @@ func CanLoadTicket() (ok bool, err error) {
 
 // LoadTicket deserializes the cached ticket file and returns it.
 // Should be prefaced with calling CanLoadTicket().
-func LoadTicket() (t *Ticket, err error) {
+func LoadTicket() (*Ticket, error) {
+	return LoadTicketFrom(DefaultTicketFile)
+}
+
+// LoadTicketFrom performs the same as LoadTicket, but uses the file at path.
+func LoadTicketFrom(path string) (t *Ticket, err error) {
 	var file *os.File
-	file, err = os.Open(ticketFile)
+	file, err = os.Open(path)
 	if err != nil {
 		return
 	}
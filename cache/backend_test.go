@@ -0,0 +1,232 @@
+package cache
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis is an in-memory RedisClient supporting glob-style patterns with escapes.
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string][]byte
+	expires map[string]time.Time
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{values: map[string][]byte{}, expires: map[string]time.Time{}}
+}
+
+func (f *fakeRedis) Get(key string) ([]byte, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if time.Now().After(f.expires[key]) {
+		delete(f.values, key)
+		delete(f.expires, key)
+	}
+	value, ok := f.values[key]
+	return value, ok, nil
+}
+
+func (f *fakeRedis) Set(key string, value []byte, ttl time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[key] = value
+	f.expires[key] = time.Now().Add(ttl)
+	return nil
+}
+
+func (f *fakeRedis) Keys(pattern string) ([]string, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '\\':
+			i++
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	re := regexp.MustCompile(expr.String())
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for key := range f.values {
+		if re.MatchString(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (f *fakeRedis) Del(keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, key := range keys {
+		delete(f.values, key)
+		delete(f.expires, key)
+	}
+	return nil
+}
+
+func newTestBackends(t *testing.T) map[string]Backend {
+	t.Helper()
+	disk, err := NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]Backend{
+		"memory": NewMemory(100),
+		"disk":   disk,
+		"redis":  NewRedis(newFakeRedis(), "r6api:"),
+	}
+}
+
+func assertGet(t *testing.T, b Backend, namespace string, key string, want string, wantFound bool) {
+	t.Helper()
+	value, found, err := b.Get(namespace, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != wantFound || string(value) != want {
+		t.Errorf("%s/%s: want ('%s', %t), got ('%s', %t)", namespace, key, want, wantFound, value, found)
+	}
+}
+
+func TestBackends(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, b Backend)
+	}{
+		{
+			name: "missing",
+			run: func(t *testing.T, b Backend) {
+				assertGet(t, b, "profile", "key", "", false)
+			},
+		},
+		{
+			name: "round trip",
+			run: func(t *testing.T, b Backend) {
+				if err := b.Set("profile", "https://example.com/stats?a=1", []byte("value"), time.Minute); err != nil {
+					t.Fatal(err)
+				}
+				assertGet(t, b, "profile", "https://example.com/stats?a=1", "value", true)
+				assertGet(t, b, "other", "https://example.com/stats?a=1", "", false)
+			},
+		},
+		{
+			name: "overwrite",
+			run: func(t *testing.T, b Backend) {
+				for _, value := range []string{"old", "new"} {
+					if err := b.Set("profile", "key", []byte(value), time.Minute); err != nil {
+						t.Fatal(err)
+					}
+				}
+				assertGet(t, b, "profile", "key", "new", true)
+			},
+		},
+		{
+			name: "expiry",
+			run: func(t *testing.T, b Backend) {
+				if err := b.Set("profile", "key", []byte("value"), 10*time.Millisecond); err != nil {
+					t.Fatal(err)
+				}
+				time.Sleep(20 * time.Millisecond)
+				assertGet(t, b, "profile", "key", "", false)
+			},
+		},
+		{
+			name: "purge",
+			run: func(t *testing.T, b Backend) {
+				for _, namespace := range []string{"profile", "profile2", "other"} {
+					if err := b.Set(namespace, "key", []byte(namespace), time.Minute); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := b.Purge("profile"); err != nil {
+					t.Fatal(err)
+				}
+				assertGet(t, b, "profile", "key", "", false)
+				assertGet(t, b, "profile2", "key", "profile2", true)
+				assertGet(t, b, "other", "key", "other", true)
+				if err := b.Purge("missing"); err != nil {
+					t.Errorf("purging a missing namespace should succeed, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, b := range newTestBackends(t) {
+				t.Run(name, func(t *testing.T) {
+					tt.run(t, b)
+				})
+			}
+		})
+	}
+}
+
+func TestMemoryEviction(t *testing.T) {
+	m := NewMemory(2)
+	for _, key := range []string{"a", "b"} {
+		if err := m.Set("profile", key, []byte(key), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// accessing a makes b the least recently used value
+	assertGet(t, m, "profile", "a", "a", true)
+	if err := m.Set("other", "c", []byte("c"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	assertGet(t, m, "profile", "a", "a", true)
+	assertGet(t, m, "profile", "b", "", false)
+	assertGet(t, m, "other", "c", "c", true)
+	if n := m.lru.Len(); n != 2 {
+		t.Errorf("want 2 values, got %d", n)
+	}
+
+	// stored values are not shared with callers
+	value := []byte("value")
+	if err := m.Set("profile", "copy", value, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	value[0] = 'X'
+	got, _, _ := m.Get("profile", "copy")
+	got[1] = 'X'
+	assertGet(t, m, "profile", "copy", "value", true)
+
+	if n := NewMemory(0).capacity; n != 1 {
+		t.Errorf("want capacity 1 for invalid capacity, got %d", n)
+	}
+}
+
+func TestRedisKeys(t *testing.T) {
+	client := newFakeRedis()
+	r := NewRedis(client, "r6api:")
+	for _, namespace := range []string{"a*", "ab", "a?"} {
+		if err := r.Set(namespace, "key", []byte(namespace), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok, _ := client.Get("r6api:ab:key"); !ok {
+		t.Error("want keys prefixed with prefix and namespace")
+	}
+
+	// special characters of namespaces must not match other namespaces
+	if err := r.Purge("a*"); err != nil {
+		t.Fatal(err)
+	}
+	assertGet(t, r, "a*", "key", "", false)
+	assertGet(t, r, "ab", "key", "ab", true)
+	assertGet(t, r, "a?", "key", "a?", true)
+}
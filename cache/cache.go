@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Backend stores cached responses.
+// Values are grouped by namespace (e.g. a profile ID) so they can be invalidated together.
+// Implementations need to be safe for concurrent use.
+type Backend interface {
+	// Get returns the value stored for key in namespace and whether it was found and not expired yet.
+	Get(namespace string, key string) ([]byte, bool, error)
+	// Set stores value for key in namespace, expiring after ttl.
+	Set(namespace string, key string, value []byte, ttl time.Duration) error
+	// Purge removes all values stored in namespace.
+	Purge(namespace string) error
+}
+
+// BackendError is returned by Cache.Fetch if the backend failed, but the value could still be fetched.
+// The value returned alongside it is valid.
+type BackendError struct {
+	Err error
+}
+
+func (e *BackendError) Error() string {
+	return fmt.Sprintf("cache backend error: %v", e.Err)
+}
+
+func (e *BackendError) Unwrap() error {
+	return e.Err
+}
+
+// Cache caches responses in a Backend, deduplicating concurrent fetches of the same key.
+type Cache struct {
+	backend    Backend
+	defaultTTL time.Duration
+	ttls       map[string]time.Duration
+	ttlsMu     sync.RWMutex
+	group      group
+
+	// generations counts the invalidations per namespace, so fetches started before an invalidation do not store their value.
+	generations   map[string]uint64
+	generationsMu sync.RWMutex
+}
+
+// New creates a new cache storing values in backend.
+// defaultTTL is used for all aggregation types without a TTL configured via SetTTL.
+func New(backend Backend, defaultTTL time.Duration) *Cache {
+	return &Cache{
+		backend:     backend,
+		defaultTTL:  defaultTTL,
+		ttls:        map[string]time.Duration{},
+		generations: map[string]uint64{},
+	}
+}
+
+// SetTTL configures the TTL for values of a specific aggregation type (e.g. "summary" or "operators").
+// A TTL of 0 disables caching for this aggregation type, concurrent fetches are still deduplicated.
+func (c *Cache) SetTTL(aggregation string, ttl time.Duration) {
+	c.ttlsMu.Lock()
+	defer c.ttlsMu.Unlock()
+	c.ttls[aggregation] = ttl
+}
+
+func (c *Cache) ttl(aggregation string) time.Duration {
+	c.ttlsMu.RLock()
+	defer c.ttlsMu.RUnlock()
+	if ttl, ok := c.ttls[aggregation]; ok {
+		return ttl
+	}
+	return c.defaultTTL
+}
+
+func (c *Cache) generation(namespace string) uint64 {
+	c.generationsMu.RLock()
+	defer c.generationsMu.RUnlock()
+	return c.generations[namespace]
+}
+
+// Fetch returns the value cached for key in namespace or calls fetch and caches its result if not cached.
+// Concurrent calls for the same key share a single call to fetch.
+// A value fetched while namespace is invalidated is returned, but not cached.
+// If the backend fails, the value is fetched regardless and a *BackendError is returned alongside it.
+func (c *Cache) Fetch(namespace string, aggregation string, key string, fetch func() ([]byte, error)) ([]byte, error) {
+	generation := c.generation(namespace)
+	// calls started after an invalidation do not join fetches started before it
+	return c.group.do(fmt.Sprintf("%s\x00%d\x00%s", namespace, generation, key), func() ([]byte, error) {
+		ttl := c.ttl(aggregation)
+		var backendErrs []error
+
+		if ttl > 0 {
+			value, found, err := c.backend.Get(namespace, key)
+			if err != nil {
+				backendErrs = append(backendErrs, err)
+			} else if found {
+				return value, nil
+			}
+		}
+
+		value, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+
+		if ttl > 0 {
+			if err = c.set(namespace, generation, key, value, ttl); err != nil {
+				backendErrs = append(backendErrs, err)
+			}
+		}
+		if len(backendErrs) > 0 {
+			return value, &BackendError{Err: errors.Join(backendErrs...)}
+		}
+		return value, nil
+	})
+}
+
+// set stores value in the backend unless namespace was invalidated since generation.
+func (c *Cache) set(namespace string, generation uint64, key string, value []byte, ttl time.Duration) error {
+	// holding the read lock while storing prevents Invalidate from purging in between
+	c.generationsMu.RLock()
+	defer c.generationsMu.RUnlock()
+	if c.generations[namespace] != generation {
+		return nil
+	}
+	return c.backend.Set(namespace, key, value, ttl)
+}
+
+// Invalidate removes all values cached in namespace.
+// Fetches of namespace which are still in flight do not cache their values afterwards.
+func (c *Cache) Invalidate(namespace string) error {
+	c.generationsMu.Lock()
+	defer c.generationsMu.Unlock()
+	c.generations[namespace]++
+	return c.backend.Purge(namespace)
+}
+
+// group deduplicates concurrent calls with the same key.
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+type call struct {
+	wg    sync.WaitGroup
+	value []byte
+	err   error
+}
+
+func (g *group) do(key string, fn func() ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*call{}
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.value, c.err
+	}
+	c := new(call)
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.value, c.err = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	return c.value, c.err
+}
@@ -0,0 +1,204 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingBackend fails every operation.
+type failingBackend struct{}
+
+var errBackend = errors.New("backend unavailable")
+
+func (failingBackend) Get(string, string) ([]byte, bool, error)        { return nil, false, errBackend }
+func (failingBackend) Set(string, string, []byte, time.Duration) error { return errBackend }
+func (failingBackend) Purge(string) error                              { return errBackend }
+
+// countingFetch returns a fetch function returning value and the number of its calls.
+func countingFetch(value string) (func() ([]byte, error), *int32) {
+	calls := new(int32)
+	return func() ([]byte, error) {
+		atomic.AddInt32(calls, 1)
+		return []byte(value), nil
+	}, calls
+}
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttls      map[string]time.Duration
+		wantCalls int32
+	}{
+		{
+			name:      "default ttl",
+			wantCalls: 1,
+		},
+		{
+			name:      "aggregation ttl",
+			ttls:      map[string]time.Duration{"summary": time.Hour},
+			wantCalls: 1,
+		},
+		{
+			name:      "disabled for aggregation",
+			ttls:      map[string]time.Duration{"summary": 0},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(NewMemory(10), time.Minute)
+			for aggregation, ttl := range tt.ttls {
+				c.SetTTL(aggregation, ttl)
+			}
+			fetch, calls := countingFetch("value")
+			for i := 0; i < 2; i++ {
+				value, err := c.Fetch("profile", "summary", "key", fetch)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(value) != "value" {
+					t.Errorf("want 'value', got '%s'", value)
+				}
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("want %d fetches, got %d", tt.wantCalls, *calls)
+			}
+		})
+	}
+}
+
+func TestFetchError(t *testing.T) {
+	c := New(NewMemory(10), time.Minute)
+	errFetch := errors.New("fetch failed")
+	if _, err := c.Fetch("profile", "summary", "key", func() ([]byte, error) { return nil, errFetch }); !errors.Is(err, errFetch) {
+		t.Fatalf("want fetch error, got %v", err)
+	}
+
+	// errors are not cached
+	fetch, calls := countingFetch("value")
+	if _, err := c.Fetch("profile", "summary", "key", fetch); err != nil || *calls != 1 {
+		t.Errorf("want fetch after error, got %d fetches (%v)", *calls, err)
+	}
+}
+
+func TestFetchBackendError(t *testing.T) {
+	c := New(failingBackend{}, time.Minute)
+	fetch, _ := countingFetch("value")
+	value, err := c.Fetch("profile", "summary", "key", fetch)
+	var backendErr *BackendError
+	if !errors.As(err, &backendErr) || !errors.Is(err, errBackend) {
+		t.Fatalf("want *BackendError, got %v", err)
+	}
+	if string(value) != "value" {
+		t.Errorf("want value despite backend error, got '%s'", value)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	c := New(NewMemory(10), time.Minute)
+	fetch, calls := countingFetch("value")
+	for _, namespace := range []string{"profile", "other"} {
+		if _, err := c.Fetch(namespace, "summary", "key", fetch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Invalidate("profile"); err != nil {
+		t.Fatal(err)
+	}
+	for _, namespace := range []string{"profile", "other"} {
+		if _, err := c.Fetch(namespace, "summary", "key", fetch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// only the invalidated namespace is fetched again
+	if *calls != 3 {
+		t.Errorf("want 3 fetches, got %d", *calls)
+	}
+}
+
+func TestInvalidateInFlight(t *testing.T) {
+	c := New(NewMemory(10), time.Minute)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := c.Fetch("profile", "summary", "key", func() ([]byte, error) {
+			close(started)
+			<-release
+			return []byte("stale"), nil
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+	<-started
+	if err := c.Invalidate("profile"); err != nil {
+		t.Fatal(err)
+	}
+
+	// fetches after the invalidation do not join the one in flight
+	value, err := c.Fetch("profile", "summary", "key", func() ([]byte, error) {
+		return []byte("fresh"), nil
+	})
+	if err != nil || string(value) != "fresh" {
+		t.Fatalf("want 'fresh', got '%s' (%v)", value, err)
+	}
+	close(release)
+	<-done
+
+	fetch, calls := countingFetch("refetched")
+	if value, _ = c.Fetch("profile", "summary", "key", fetch); string(value) != "fresh" || *calls != 0 {
+		t.Errorf("fetch started before invalidation should not be cached, got '%s'", value)
+	}
+}
+
+func TestFetchConcurrent(t *testing.T) {
+	const n = 50
+	c := New(NewMemory(10), 0)
+	release := make(chan struct{})
+	var calls int32
+	fetch := func() ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []byte("value"), nil
+	}
+
+	var (
+		wg      sync.WaitGroup
+		started sync.WaitGroup
+		results = make(chan string, n)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		started.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			value, err := c.Fetch("profile", "summary", "key", fetch)
+			if err != nil {
+				t.Error(err)
+			}
+			results <- string(value)
+		}()
+	}
+	started.Wait()
+	// give all goroutines the chance to join the pending fetch before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if calls != 1 {
+		t.Errorf("want 1 fetch for %d concurrent identical requests, got %d", n, calls)
+	}
+	for value := range results {
+		if value != "value" {
+			t.Errorf("want 'value' for every request, got '%s'", value)
+		}
+	}
+}
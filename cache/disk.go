@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Disk is a Backend storing values as files in a directory, one subdirectory per namespace.
+// Expired values are removed lazily when accessed.
+type Disk struct {
+	dir string
+}
+
+type diskEntry struct {
+	Expires time.Time `json:"expires"`
+	Value   []byte    `json:"value"`
+}
+
+// NewDisk creates a new disk backend storing its files in dir, creating it if it does not exist.
+func NewDisk(dir string) (*Disk, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Disk{dir: dir}, nil
+}
+
+// hashName returns a file name for s which is safe to use on any file system.
+func hashName(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func (d *Disk) namespaceDir(namespace string) string {
+	return filepath.Join(d.dir, hashName(namespace))
+}
+
+func (d *Disk) path(namespace string, key string) string {
+	return filepath.Join(d.namespaceDir(namespace), hashName(key)+".json")
+}
+
+func (d *Disk) Get(namespace string, key string) ([]byte, bool, error) {
+	path := d.path(namespace, key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	var entry diskEntry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil, false, err
+	}
+	if time.Now().After(entry.Expires) {
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, false, err
+		}
+		return nil, false, nil
+	}
+	return entry.Value, true, nil
+}
+
+func (d *Disk) Set(namespace string, key string, value []byte, ttl time.Duration) (err error) {
+	var data []byte
+	data, err = json.Marshal(diskEntry{
+		Expires: time.Now().Add(ttl),
+		Value:   value,
+	})
+	if err != nil {
+		return
+	}
+	dir := d.namespaceDir(namespace)
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	// write to a temporary file first so concurrent readers never see partially written files
+	var file *os.File
+	file, err = os.CreateTemp(dir, "*.tmp")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(file.Name()))
+		}
+	}()
+	if _, err = file.Write(data); err != nil {
+		err = errors.Join(err, file.Close())
+		return
+	}
+	if err = file.Close(); err != nil {
+		return
+	}
+	err = os.Rename(file.Name(), d.path(namespace, key))
+	return
+}
+
+func (d *Disk) Purge(namespace string) error {
+	return os.RemoveAll(d.namespaceDir(namespace))
+}
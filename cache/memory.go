@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Memory is an in-memory Backend which evicts the least recently used values once its capacity is reached.
+type Memory struct {
+	capacity   int
+	lru        *list.List
+	namespaces map[string]map[string]*list.Element
+	mu         sync.Mutex
+}
+
+type memoryEntry struct {
+	namespace string
+	key       string
+	value     []byte
+	expires   time.Time
+}
+
+// NewMemory creates a new in-memory backend holding at most capacity values.
+// Values lower than 1 are treated as 1.
+func NewMemory(capacity int) *Memory {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Memory{
+		capacity:   capacity,
+		lru:        list.New(),
+		namespaces: map[string]map[string]*list.Element{},
+	}
+}
+
+func (m *Memory) Get(namespace string, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.namespaces[namespace][key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*memoryEntry)
+	if time.Now().After(entry.expires) {
+		m.remove(elem)
+		return nil, false, nil
+	}
+	m.lru.MoveToFront(elem)
+	// callers must not be able to modify the stored value
+	return append([]byte(nil), entry.value...), true, nil
+}
+
+func (m *Memory) Set(namespace string, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value = append([]byte(nil), value...)
+	expires := time.Now().Add(ttl)
+	if elem, ok := m.namespaces[namespace][key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value = value
+		entry.expires = expires
+		m.lru.MoveToFront(elem)
+		return nil
+	}
+
+	elem := m.lru.PushFront(&memoryEntry{
+		namespace: namespace,
+		key:       key,
+		value:     value,
+		expires:   expires,
+	})
+	if m.namespaces[namespace] == nil {
+		m.namespaces[namespace] = map[string]*list.Element{}
+	}
+	m.namespaces[namespace][key] = elem
+
+	for m.lru.Len() > m.capacity {
+		m.remove(m.lru.Back())
+	}
+	return nil
+}
+
+func (m *Memory) Purge(namespace string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, elem := range m.namespaces[namespace] {
+		m.lru.Remove(elem)
+	}
+	delete(m.namespaces, namespace)
+	return nil
+}
+
+// remove removes elem from both the LRU list and the namespace index, needs to be called while holding the lock.
+func (m *Memory) remove(elem *list.Element) {
+	entry := m.lru.Remove(elem).(*memoryEntry)
+	delete(m.namespaces[entry.namespace], entry.key)
+	if len(m.namespaces[entry.namespace]) == 0 {
+		delete(m.namespaces, entry.namespace)
+	}
+}
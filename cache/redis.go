@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"strings"
+	"time"
+)
+
+// RedisClient contains the Redis commands required by the Redis backend.
+// It can be implemented with a thin wrapper around any Redis (or Redis-compatible) client library.
+type RedisClient interface {
+	// Get returns the value of key (GET) and whether it exists.
+	Get(key string) ([]byte, bool, error)
+	// Set sets the value of key, expiring after ttl (SET with PX).
+	Set(key string, value []byte, ttl time.Duration) error
+	// Keys returns all keys matching the glob-style pattern (KEYS or SCAN).
+	Keys(pattern string) ([]string, error)
+	// Del removes the provided keys (DEL).
+	Del(keys ...string) error
+}
+
+// Redis is a Backend storing values in Redis, relying on Redis for expiry.
+type Redis struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedis creates a new Redis backend, prefixing all keys with prefix (e.g. "r6api:").
+func NewRedis(client RedisClient, prefix string) *Redis {
+	return &Redis{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// redisPatternEscaper escapes characters with special meaning in Redis glob-style patterns.
+var redisPatternEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+func (r *Redis) namespacePrefix(namespace string) string {
+	return r.prefix + namespace + ":"
+}
+
+func (r *Redis) Get(namespace string, key string) ([]byte, bool, error) {
+	return r.client.Get(r.namespacePrefix(namespace) + key)
+}
+
+func (r *Redis) Set(namespace string, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(r.namespacePrefix(namespace)+key, value, ttl)
+}
+
+func (r *Redis) Purge(namespace string) error {
+	keys, err := r.client.Keys(redisPatternEscaper.Replace(r.namespacePrefix(namespace)) + "*")
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.client.Del(keys...)
+}
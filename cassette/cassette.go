@@ -0,0 +1,141 @@
+// Package cassette provides HTTP transports recording traffic to a cassette file and replaying it later.
+// This can be used to capture real API responses as fixtures or to reproduce parsing bugs offline:
+//
+//	rec, err := cassette.NewRecorder("session.jsonl", nil)
+//	// handle err
+//	defer rec.Close()
+//	a := r6api.NewR6API(email, password, logger, r6api.WithHTTPClient(&http.Client{Transport: rec}))
+//
+// Cassettes contain one JSON-encoded Interaction per line.
+// Credentials, tickets and session IDs are scrubbed before writing.
+package cassette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Interaction is a single recorded request/response pair.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the recorded part of an HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is the recorded part of an HTTP response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Scrubbed replaces every secret value in recorded interactions.
+const Scrubbed = "[scrubbed]"
+
+// scrubbedHeaders contains headers carrying credentials, tickets or session IDs.
+var scrubbedHeaders = []string{"Authorization", "Ubi-SessionId", "Cookie", "Set-Cookie"}
+
+// scrubbedFields contains JSON fields carrying tickets or session IDs, e.g. in the login response.
+var scrubbedFields = map[string]bool{
+	"ticket":                        true,
+	"sessionId":                     true,
+	"rememberMeTicket":              true,
+	"rememberDeviceTicket":          true,
+	"twoFactorAuthenticationTicket": true,
+}
+
+func scrubHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	h = h.Clone()
+	for _, name := range scrubbedHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, Scrubbed)
+		}
+	}
+	return h
+}
+
+// scrubBody replaces the values of scrubbedFields at any depth of a JSON body.
+// Bodies which are not JSON or do not contain any of the fields are returned unchanged.
+func scrubBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() || !scrubValue(v) {
+		return string(body)
+	}
+
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return string(body)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// scrubValue replaces the values of scrubbedFields in v, returning true if any value was replaced.
+func scrubValue(v any) (scrubbed bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if scrubbedFields[key] && value != nil {
+				v[key] = Scrubbed
+				scrubbed = true
+			} else if scrubValue(value) {
+				scrubbed = true
+			}
+		}
+	case []any:
+		for _, elem := range v {
+			if scrubValue(elem) {
+				scrubbed = true
+			}
+		}
+	}
+	return
+}
+
+// Load reads all interactions from the cassette at path.
+func Load(path string) (interactions []Interaction, err error) {
+	var file *os.File
+	file, err = os.Open(path)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	scanner := bufio.NewScanner(file)
+	// responses can easily exceed the default token size
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var i Interaction
+		if err = json.Unmarshal(scanner.Bytes(), &i); err != nil {
+			err = fmt.Errorf("invalid interaction in %s:%d: %w", path, line, err)
+			return
+		}
+		interactions = append(interactions, i)
+	}
+	err = scanner.Err()
+	return
+}
@@ -0,0 +1,85 @@
+package cassette_test
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stnokott/r6api"
+	"github.com/stnokott/r6api/cassette"
+	"github.com/stnokott/r6api/r6apitest"
+	"github.com/stnokott/r6api/types/stats"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	srv := r6apitest.NewServer()
+	defer srv.Close()
+
+	rec, err := cassette.NewRecorder(path, srv.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := new(stats.SummarizedStats)
+	a := r6api.NewR6API("test@example.com", "password", zerolog.Nop(), r6api.WithHTTPClient(&http.Client{Transport: rec}), r6api.WithTicketFile(""))
+	profile, err := a.ResolveUser("TestUser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = a.GetStats(profile, "Y8S2", recorded); err != nil {
+		t.Fatal(err)
+	}
+	if err = rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("scrubbed", func(t *testing.T) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, secret := range []string{"Basic ", "ubi_v1 t=", "ticket-1", "session-1"} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("cassette contains secret %q", secret)
+			}
+		}
+		interactions, err := cassette.Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(interactions) != 3 {
+			t.Errorf("want 3 interactions, got %d", len(interactions))
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		replayer, err := cassette.NewReplayer(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := srv.Requests(r6apitest.PlayerStats)
+		// recorded ticket is scrubbed, but logging in again is served from the cassette as well
+		b := r6api.NewR6API("test@example.com", "password", zerolog.Nop(), r6api.WithHTTPClient(&http.Client{Transport: replayer}), r6api.WithTicketFile(""))
+		for i := 0; i < 2; i++ {
+			replayed := new(stats.SummarizedStats)
+			if err = b.GetStats(profile, "Y8S2", replayed); err != nil {
+				t.Fatal(err)
+			}
+			if replayed.Ranked.All.Kills != recorded.Ranked.All.Kills {
+				t.Errorf("want %d kills, got %d", recorded.Ranked.All.Kills, replayed.Ranked.All.Kills)
+			}
+		}
+		if srv.Requests(r6apitest.PlayerStats) != before {
+			t.Error("replay performed network requests")
+		}
+
+		err = b.GetStats(profile, "Y8S1", new(stats.SummarizedStats))
+		if !errors.Is(err, cassette.ErrNoInteraction) {
+			t.Errorf("want ErrNoInteraction, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package cassette
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrNoInteraction is returned by Replayer if the cassette does not contain a matching interaction.
+var ErrNoInteraction = errors.New("no matching interaction in cassette")
+
+// Replayer is a http.RoundTripper which serves recorded interactions without performing any network requests.
+// It is safe for concurrent use.
+//
+// Requests are matched by method, URL and body.
+// Interactions matching the same request are served in recorded order, repeating the last one once exhausted.
+// This keeps replays deterministic even if the client sends a request more often than during recording,
+// e.g. logging in again because the recorded ticket has expired in the meantime.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions map[string][]Interaction
+	served       map[string]int
+}
+
+// NewReplayer creates a new Replayer serving the interactions from the cassette at path.
+func NewReplayer(path string) (*Replayer, error) {
+	interactions, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Replayer{
+		interactions: map[string][]Interaction{},
+		served:       map[string]int{},
+	}
+	for _, i := range interactions {
+		key := matchKey(i.Request.Method, i.Request.URL, i.Request.Body)
+		r.interactions[key] = append(r.interactions[key], i)
+	}
+	return r, nil
+}
+
+func matchKey(method string, url string, body string) string {
+	return strings.Join([]string{method, url, scrubBody([]byte(body))}, " ")
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err = errors.Join(err, req.Body.Close()); err != nil {
+			return nil, err
+		}
+	}
+
+	i, ok := r.next(matchKey(req.Method, req.URL.String(), string(reqBody)))
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+	}
+
+	header := i.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+		StatusCode:    i.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(i.Response.Body)),
+		ContentLength: int64(len(i.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Replayer) next(key string) (i Interaction, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	candidates := r.interactions[key]
+	if len(candidates) == 0 {
+		return
+	}
+	n := r.served[key]
+	if n >= len(candidates) {
+		n = len(candidates) - 1
+	} else {
+		r.served[key]++
+	}
+	return candidates[n], true
+}
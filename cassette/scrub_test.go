@@ -0,0 +1,49 @@
+package cassette
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScrubBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		want   string
+		secret string
+	}{
+		{
+			name:   "escaped quote",
+			body:   `{"ticket": "abc\"def", "sessionId": "s1"}`,
+			want:   `{"sessionId":"[scrubbed]","ticket":"[scrubbed]"}`,
+			secret: "def",
+		},
+		{
+			name:   "nested",
+			body:   `{"profiles": [{"rememberMeTicket": "xyz", "nameOnPlatform": "<User>", "level": 12345678901234567890}]}`,
+			want:   `{"profiles":[{"level":12345678901234567890,"nameOnPlatform":"<User>","rememberMeTicket":"[scrubbed]"}]}`,
+			secret: "xyz",
+		},
+		{
+			name: "without secrets",
+			body: `{"kills":  3}`,
+			want: `{"kills":  3}`,
+		},
+		{
+			name: "not JSON",
+			body: `<html>"ticket": "abc"</html>`,
+			want: `<html>"ticket": "abc"</html>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scrubBody([]byte(tt.body))
+			if got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+			if tt.secret != "" && strings.Contains(got, tt.secret) {
+				t.Errorf("secret %q not scrubbed", tt.secret)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/robertkrimen/otto v0.2.1
 	github.com/rs/zerolog v1.29.1
+	golang.org/x/text v0.7.0
 )
 
 require (
@@ -15,7 +16,6 @@ require (
 	github.com/mattn/go-isatty v0.0.16 // indirect
 	golang.org/x/net v0.7.0 // indirect
 	golang.org/x/sys v0.5.0 // indirect
-	golang.org/x/text v0.7.0 // indirect
 	gopkg.in/sourcemap.v1 v1.0.5 // indirect
 )
 
@@ -0,0 +1,57 @@
+// Package cataloguetest contains helpers for testing the catalogues of this module (operators, weapons and maps).
+package cataloguetest
+
+import (
+	"testing"
+
+	"github.com/stnokott/r6api/types/stats"
+)
+
+// Detailed returns detailed stats with the provided kills and rounds played, all other stats are zero.
+func Detailed(kills int, rounds int) stats.DetailedStats {
+	var s stats.DetailedStats
+	s.Kills = kills
+	s.RoundsPlayed = rounds
+	return s
+}
+
+// Weapon returns weapon stats with the provided kills and rounds played, all other stats are zero.
+func Weapon(kills int, rounds int) stats.WeaponNamedStats {
+	var s stats.WeaponNamedStats
+	s.Kills = kills
+	s.RoundsPlayed = rounds
+	return s
+}
+
+// LookupCase is a name to look up in a catalogue together with the canonical name of the expected entry.
+// Want is empty if the lookup should not find any entry.
+type LookupCase struct {
+	Name string
+	Want string
+}
+
+// RunLookups runs a subtest per case, checking that lookup returns the canonical name of the entry found for its name.
+// lookup should return false if no entry is found.
+func RunLookups(t *testing.T, lookup func(name string) (string, bool), cases ...LookupCase) {
+	t.Helper()
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			got, ok := lookup(tt.Name)
+			if ok != (tt.Want != "") || got != tt.Want {
+				t.Errorf("want '%s', got '%s' (%t)", tt.Want, got, ok)
+			}
+		})
+	}
+}
+
+// CheckUnique reports an error for every key occurring more than once, describing them as what (e.g. "icon slug").
+func CheckUnique(t *testing.T, what string, keys []string) {
+	t.Helper()
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			t.Errorf("duplicate %s %s", what, key)
+		}
+		seen[key] = true
+	}
+}
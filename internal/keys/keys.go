@@ -0,0 +1,47 @@
+// Package keys contains helpers for the keys of lookup tables, shared by the catalogues of this module.
+package keys
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// unsplittable maps letters which do not decompose into a base letter and a diacritic to their (upper case) base letters.
+var unsplittable = map[rune]string{
+	'ø': "O", 'Ø': "O",
+	'æ': "AE", 'Æ': "AE",
+	'œ': "OE", 'Œ': "OE",
+	'ß': "SS",
+	'ł': "L", 'Ł': "L",
+	'đ': "D", 'Đ': "D",
+}
+
+// Normalize returns the key of a name, which ignores case, diacritics, whitespace and punctuation,
+// so that e.g. "Jäger" and "JAGER" or "P10 RONI" and "P10-RONI" share the same key.
+func Normalize(name string) string {
+	var b strings.Builder
+	// decomposing separates diacritics from their letters, the diacritics are then skipped as they are neither letters nor digits
+	for _, r := range norm.NFD.String(name) {
+		if base, ok := unsplittable[r]; ok {
+			b.WriteString(base)
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
+
+// Sorted returns the keys of m in ascending order.
+func Sorted[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
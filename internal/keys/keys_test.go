@@ -0,0 +1,24 @@
+package keys
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Jäger", want: "JAGER"},
+		{name: "Nøkk", want: "NOKK"},
+		{name: "Capitão", want: "CAPITAO"},
+		{name: "P10-RONI", want: "P10RONI"},
+		{name: "Kids' Dorms", want: "KIDSDORMS"},
+		{name: "Schlafzimmer (Kinder)", want: "SCHLAFZIMMERKINDER"},
+		{name: "Cuisine équipée", want: "CUISINEEQUIPEE"},
+		{name: "Große Halle", want: "GROSSEHALLE"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.name); got != tt.want {
+			t.Errorf("%s: want %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Package ratio contains helpers for deriving ratios from counts.
+package ratio
+
+// Of returns numerator divided by denominator, or 0 if denominator is 0.
+func Of(numerator int, denominator int) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return float64(numerator) / float64(denominator)
+}
+
+// KD returns the ratio of kills to deaths, treating 0 deaths as 1 as done in-game,
+// so that a player with kills but no deaths ranks above players with fewer kills.
+func KD(kills int, deaths int) float64 {
+	if deaths < 1 {
+		deaths = 1
+	}
+	return float64(kills) / float64(deaths)
+}
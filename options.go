@@ -0,0 +1,185 @@
+package r6api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/stnokott/r6api/cache"
+	"github.com/stnokott/r6api/types/stats"
+)
+
+// Option configures an R6API instance created by NewR6API.
+type Option func(*R6API)
+
+// WithCache enables caching of stats responses in c.
+// Concurrent requests for the same stats are deduplicated, responses are cached per profile for the TTLs configured in c.
+func WithCache(c *cache.Cache) Option {
+	return func(a *R6API) {
+		a.cache = c
+	}
+}
+
+// WithHTTPClient uses c for all requests instead of http.DefaultClient.
+// This can be used to configure timeouts or custom transports, e.g. for testing.
+func WithHTTPClient(c *http.Client) Option {
+	return func(a *R6API) {
+		a.httpClient = c
+	}
+}
+
+// WithTicketFile caches the auth ticket in the file at path instead of auth.DefaultTicketFile.
+// An empty path disables caching the ticket on disk, requiring a login for every new instance.
+func WithTicketFile(path string) Option {
+	return func(a *R6API) {
+		a.ticketFile = path
+	}
+}
+
+// DefaultMetadataTTL is the time metadata is cached for by default, see WithMetadataTTL.
+const DefaultMetadataTTL = 24 * time.Hour
+
+// WithMetadataTTL caches the metadata returned by GetMetadata for ttl instead of DefaultMetadataTTL.
+// A ttl of 0 disables caching, retrieving the glossary page on every call.
+func WithMetadataTTL(ttl time.Duration) Option {
+	return func(a *R6API) {
+		a.metadataTTL = ttl
+	}
+}
+
+// WithMetadataFile persists the metadata retrieved by GetMetadata as a snapshot to the file at path.
+// A snapshot younger than the metadata TTL is used instead of retrieving the glossary page, e.g. after a restart.
+// Older snapshots are only used if the glossary page cannot be retrieved or parsed.
+// Disabled by default.
+func WithMetadataFile(path string) Option {
+	return func(a *R6API) {
+		a.metadataFile = path
+	}
+}
+
+// StatsOption configures requests made by GetStats and GetStatsRange.
+type StatsOption func(*statsOptions)
+
+type statsOptions struct {
+	query               *stats.StatsQuery
+	bombsites           bool
+	bombsiteConcurrency int
+	decode              stats.DecodeOptions
+}
+
+const defaultBombsiteConcurrency = 4
+
+func newStatsOptions(opts []StatsOption) *statsOptions {
+	o := &statsOptions{
+		query:               stats.NewStatsQuery(),
+		bombsites:           true,
+		bombsiteConcurrency: defaultBombsiteConcurrency,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithQuery uses q for the request, e.g. to filter by maps or operators.
+// The aggregation of q is ignored in favour of the provider's, its view only overrides the provider's if set.
+// Its seasons are only used if no season is passed to GetStats.
+// Replaces any filters set by previous options, subsequent options modify a copy of q.
+func WithQuery(q *stats.StatsQuery) StatsOption {
+	return func(o *statsOptions) {
+		o.query = q.Clone()
+	}
+}
+
+// WithView overrides the view of the provider (e.g. "seasonal" for stats.WeaponStats, which uses "current" by default).
+// Only views supported by the provider's aggregation should be used, see the documentation of the providers.
+func WithView(view string) StatsOption {
+	return func(o *statsOptions) {
+		o.query.View(view)
+	}
+}
+
+// WithGameModes restricts the requested stats to the provided game modes.
+// All game modes are requested by default.
+func WithGameModes(gameModes ...stats.GameMode) StatsOption {
+	return func(o *statsOptions) {
+		o.query.GameModes(gameModes...)
+	}
+}
+
+// WithTeamRoles restricts the requested stats to the provided team roles.
+// All team roles are requested by default.
+func WithTeamRoles(teamRoles ...stats.TeamRole) StatsOption {
+	return func(o *statsOptions) {
+		o.query.TeamRoles(teamRoles...)
+	}
+}
+
+// WithMaps restricts the requested stats to the provided maps (e.g. "CLUBHOUSE"), e.g. to get operator stats on a single map.
+// All maps are requested by default.
+func WithMaps(maps ...string) StatsOption {
+	return func(o *statsOptions) {
+		o.query.Maps(maps...)
+	}
+}
+
+// WithOperators restricts the requested stats to the provided operators (e.g. "Ash"), e.g. to get map stats of a single operator.
+// All operators are requested by default.
+func WithOperators(operators ...string) StatsOption {
+	return func(o *statsOptions) {
+		o.query.Operators(operators...)
+	}
+}
+
+// WithBombsites controls whether map stats are enriched with bombsite stats, which requires one additional request per played map.
+// Enabled by default, has no effect for providers other than stats.MapStats.
+func WithBombsites(enabled bool) StatsOption {
+	return func(o *statsOptions) {
+		o.bombsites = enabled
+	}
+}
+
+// WithBombsiteConcurrency sets the maximum number of concurrent requests when enriching map stats with bombsite stats.
+// Defaults to 4, values lower than 1 are treated as 1.
+func WithBombsiteConcurrency(n int) StatsOption {
+	return func(o *statsOptions) {
+		if n < 1 {
+			n = 1
+		}
+		o.bombsiteConcurrency = n
+	}
+}
+
+// WithStrictDecoding enables strict decoding, reporting differences between responses and the known schema
+// such as unknown or missing fields, which are logged as warnings and available via stats.Decodable.
+// If failOnIssues is true, requests fail with a *stats.DecodeError instead if any differences are detected.
+func WithStrictDecoding(failOnIssues bool) StatsOption {
+	return func(o *statsOptions) {
+		o.decode.Strict = true
+		o.decode.FailOnIssues = failOnIssues
+	}
+}
+
+// WithRawResponse keeps the raw response in the provider, available via stats.Decodable.
+// For stats.MapStats, this only covers the map response, not the bombsite responses used for enrichment.
+func WithRawResponse() StatsOption {
+	return func(o *statsOptions) {
+		o.decode.KeepRaw = true
+	}
+}
+
+// WithTrendType sets the trend type of stats.MovingTrendStats, determining whether their points are positioned by match or by day.
+// Points are positioned by match by default.
+func WithTrendType(trendType stats.TrendType) StatsOption {
+	return func(o *statsOptions) {
+		o.query.TrendType(trendType)
+	}
+}
+
+// WithDateRange restricts the stats to matches played between start and end (both inclusive) instead of seasons,
+// so no season should be passed to GetStats. See stats.StatsQuery.URL for the restrictions of date ranges.
+// Invalid ranges, including ranges rejected by the API, make GetStats return an error wrapping stats.ErrInvalidDateRange.
+func WithDateRange(start time.Time, end time.Time) StatsOption {
+	return func(o *statsOptions) {
+		o.query.DateRange(start, end)
+	}
+}
@@ -9,9 +9,15 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/stnokott/r6api/auth"
+	"github.com/stnokott/r6api/cache"
 	"github.com/stnokott/r6api/request"
 	"github.com/stnokott/r6api/types/metadata"
 	"github.com/stnokott/r6api/types/ranked"
@@ -38,19 +44,38 @@ type R6API struct {
 	authCredentials string
 	email           string
 	ticket          *auth.Ticket
+	ticketMu        sync.Mutex
 	logger          zerolog.Logger
+	cache           *cache.Cache
+	httpClient      *http.Client
+	ticketFile      string
+
+	metadataMu     sync.Mutex
+	metadata       *metadata.Metadata
+	metadataExpiry time.Time
+	metadataErr    error // reason the cached metadata is stale, nil if it is current
+	metadataTTL    time.Duration
+	metadataFile   string
 }
 
 // NewR6API creates a new instance with the provided login credentials and logger.
-func NewR6API(email string, password string, logger zerolog.Logger) *R6API {
+// It can be configured further with opts.
+func NewR6API(email string, password string, logger zerolog.Logger, opts ...Option) *R6API {
 	authInput := []byte(email + ":" + password)
 	authCredentials := base64.StdEncoding.EncodeToString(authInput)
-	return &R6API{
+	a := &R6API{
 		authCredentials: authCredentials,
 		email:           email,
 		ticket:          nil,
 		logger:          logger,
+		httpClient:      http.DefaultClient,
+		ticketFile:      auth.DefaultTicketFile,
+		metadataTTL:     DefaultMetadataTTL,
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 const ubiLoginRequestURL string = "https://public-ubiservices.ubi.com/v3/profiles/sessions"
@@ -72,7 +97,7 @@ func (a *R6API) login() (err error) {
 	req.Header.Add("Content-Type", "application/json")
 
 	t := new(auth.Ticket)
-	err = request.JSON(req, t)
+	err = request.JSONWithClient(a.httpClient, req, t)
 	if err != nil {
 		return
 	}
@@ -81,21 +106,35 @@ func (a *R6API) login() (err error) {
 
 	a.ticket = t
 	a.logger.Info().Msgf("successfully logged in as <%s>", a.ticket.Name)
-	err = a.ticket.Save()
+	if a.ticketFile != "" {
+		err = a.ticket.SaveTo(a.ticketFile)
+	}
 	return
 }
 
 // EnsureAuth ensures the API contains an authorized, non-expired ticket by using the cached ticket or logging in again if non-existing or expired.
-func (a *R6API) EnsureAuth() (err error) {
+func (a *R6API) EnsureAuth() error {
+	_, err := a.ensureTicket()
+	return err
+}
+
+// ensureTicket performs the same as EnsureAuth, returning the valid ticket.
+// It is safe for concurrent use.
+func (a *R6API) ensureTicket() (ticket *auth.Ticket, err error) {
+	a.ticketMu.Lock()
+	defer a.ticketMu.Unlock()
+
 	loginReason := ""
 	if a.ticket == nil {
-		var canLoad bool
-		canLoad, err = auth.CanLoadTicket()
-		if err != nil {
-			return
+		canLoad := false
+		if a.ticketFile != "" {
+			canLoad, err = auth.CanLoadTicketFrom(a.ticketFile)
+			if err != nil {
+				return
+			}
 		}
 		if canLoad {
-			t, errL := auth.LoadTicket()
+			t, errL := auth.LoadTicketFrom(a.ticketFile)
 			if errL != nil {
 				err = errL
 				return
@@ -116,16 +155,29 @@ func (a *R6API) EnsureAuth() (err error) {
 
 	if loginReason != "" {
 		a.logger.Debug().Msgf("login required, reason: %s", loginReason)
-		err = a.login()
+		if err = a.login(); err != nil {
+			return
+		}
 	}
+	ticket = a.ticket
 	return
 }
 
 const ubiAppIDStats string = "3587dcbb-7f81-457c-9781-0e3f29f6f56a"
 
 // requestAuthorized executes an authorized request (i.e. with the corresponding auth headers) and attempts to unmarshal the response into dst.
-func (a *R6API) requestAuthorized(url string, dst any) (err error) {
-	if err = a.EnsureAuth(); err != nil {
+func (a *R6API) requestAuthorized(url string, dst any) error {
+	data, err := a.requestAuthorizedBytes(url)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// requestAuthorizedBytes executes an authorized request (i.e. with the corresponding auth headers) and returns the raw response.
+func (a *R6API) requestAuthorizedBytes(url string) (data []byte, err error) {
+	var ticket *auth.Ticket
+	if ticket, err = a.ensureTicket(); err != nil {
 		return
 	}
 	var req *http.Request
@@ -133,15 +185,46 @@ func (a *R6API) requestAuthorized(url string, dst any) (err error) {
 	if err != nil {
 		return
 	}
-	req.Header.Add("Ubi-AppId", ubiAppIDStats)
-	req.Header.Add("Ubi-SessionId", a.ticket.SessionID)
-	req.Header.Add("Expiration", a.ticket.Expiration.Format("2006-01-02T15:04:05.999Z"))
-	req.Header.Add("Authorization", "ubi_v1 t="+a.ticket.Token)
+	a.addAuthHeaders(req, ticket)
 
-	err = request.JSON(req, dst)
+	data, err = request.BytesWithClient(a.httpClient, req)
 	return
 }
 
+func (a *R6API) addAuthHeaders(req *http.Request, ticket *auth.Ticket) {
+	req.Header.Add("Ubi-AppId", ubiAppIDStats)
+	req.Header.Add("Ubi-SessionId", ticket.SessionID)
+	req.Header.Add("Expiration", ticket.Expiration.Format("2006-01-02T15:04:05.999Z"))
+	req.Header.Add("Authorization", "ubi_v1 t="+ticket.Token)
+}
+
+// requestStats executes an authorized request for stats of profile, using the cache if configured.
+func (a *R6API) requestStats(profile *Profile, aggregation string, url string, dst any) error {
+	if a.cache == nil {
+		return a.requestAuthorized(url, dst)
+	}
+	data, err := a.cache.Fetch(profile.ProfileID, aggregation, url, func() ([]byte, error) {
+		return a.requestAuthorizedBytes(url)
+	})
+	var backendErr *cache.BackendError
+	if errors.As(err, &backendErr) {
+		a.logger.Warn().Err(err).Str("username", profile.Name).Msg("cache unavailable, using uncached response")
+	} else if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// InvalidateCache removes all cached stats of profile.
+// Does nothing if no cache is configured.
+func (a *R6API) InvalidateCache(profile *Profile) error {
+	if a.cache == nil {
+		return nil
+	}
+	a.logger.Debug().Str("username", profile.Name).Msg("invalidating cache")
+	return a.cache.Invalidate(profile.ProfileID)
+}
+
 const ubiProfilesURLTemplate string = "https://public-ubiservices.ubi.com/v3/profiles?namesOnPlatform=%s&platformType=uplay"
 
 type ubiProfileResp struct {
@@ -177,9 +260,82 @@ func (a *R6API) ResolveUser(username string) (*Profile, error) {
 	}, nil
 }
 
+// metadataRetryInterval is the maximum time after which fetching metadata is retried after a failure.
+const metadataRetryInterval = 5 * time.Minute
+
+// ErrStaleMetadata is wrapped by the error GetMetadata returns together with metadata which might be outdated.
+var ErrStaleMetadata = errors.New("could not get current metadata")
+
 // GetMetadata retrieves information about seasons, i.e. season slug or MMR bounds.
-// This is an expensive operation as it performs Javascript evaluations.
-func (a *R6API) GetMetadata() (m *metadata.Metadata, err error) {
+// This requires downloading and parsing the glossary page, so the result is cached for the TTL configured with WithMetadataTTL
+// and persisted to the snapshot file configured with WithMetadataFile, if any.
+// If the glossary page cannot be retrieved or parsed, the most recent metadata available is returned instead,
+// i.e. previously fetched metadata, the snapshot file or metadata.Fallback, together with an error wrapping ErrStaleMetadata.
+// Its FetchedAt field tells how current it is. The metadata returned is never nil.
+// Fetching is retried on subsequent calls after at most 5 minutes, until then they return the same error.
+// The metadata returned is a copy which can be modified by the caller.
+// It is safe for concurrent use.
+func (a *R6API) GetMetadata() (*metadata.Metadata, error) {
+	a.metadataMu.Lock()
+	defer a.metadataMu.Unlock()
+
+	if a.metadata == nil && a.metadataFile != "" {
+		a.loadMetadataSnapshot()
+	}
+	now := time.Now()
+	if a.metadata != nil && now.Before(a.metadataExpiry) {
+		return a.metadata.Copy(), a.metadataErr
+	}
+
+	m, err := a.fetchMetadata()
+	if err != nil {
+		fallback := metadata.Fallback()
+		if a.metadata != nil && a.metadata.FetchedAt.After(fallback.FetchedAt) {
+			fallback = a.metadata
+		}
+		a.logger.Warn().Err(err).Time("fetchedAt", fallback.FetchedAt).Msg("could not get metadata, using previous metadata")
+		retryInterval := metadataRetryInterval
+		if a.metadataTTL < retryInterval {
+			retryInterval = a.metadataTTL
+		}
+		a.metadata = fallback
+		a.metadataExpiry = now.Add(retryInterval)
+		a.metadataErr = fmt.Errorf("%w (using metadata fetched at %s): %w", ErrStaleMetadata, fallback.FetchedAt.Format(time.RFC3339), err)
+		return fallback.Copy(), a.metadataErr
+	}
+
+	if skipped := m.SkippedSeasons(); len(skipped) > 0 {
+		a.logger.Warn().Strs("seasons", skipped).Msg("seasons cannot be looked up by ID, the season ID table might be outdated")
+	}
+	m.FetchedAt = now
+	a.metadata = m
+	a.metadataExpiry = now.Add(a.metadataTTL)
+	a.metadataErr = nil
+	if a.metadataFile != "" {
+		if err = m.SaveTo(a.metadataFile); err != nil {
+			a.logger.Warn().Err(err).Str("file", a.metadataFile).Msg("could not save metadata snapshot")
+		}
+	}
+	return m.Copy(), nil
+}
+
+// loadMetadataSnapshot loads the snapshot file as cached metadata, which expires TTL after the snapshot was taken.
+// A missing or invalid snapshot file is ignored.
+func (a *R6API) loadMetadataSnapshot() {
+	m, err := metadata.LoadFrom(a.metadataFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			a.logger.Warn().Err(err).Str("file", a.metadataFile).Msg("could not load metadata snapshot")
+		}
+		return
+	}
+	a.logger.Debug().Time("fetchedAt", m.FetchedAt).Msg("using metadata snapshot")
+	a.metadata = m
+	a.metadataExpiry = m.FetchedAt.Add(a.metadataTTL)
+}
+
+// fetchMetadata downloads and parses the glossary page.
+func (a *R6API) fetchMetadata() (m *metadata.Metadata, err error) {
 	var req *http.Request
 	req, err = http.NewRequest("GET", metadata.URL, nil)
 	if err != nil {
@@ -189,7 +345,7 @@ func (a *R6API) GetMetadata() (m *metadata.Metadata, err error) {
 
 	a.logger.Info().Msg("getting metadata")
 	var body io.ReadCloser
-	body, err = request.Plain(req)
+	body, err = request.PlainWithClient(a.httpClient, req)
 	if err != nil {
 		return
 	}
@@ -203,91 +359,224 @@ func (a *R6API) GetMetadata() (m *metadata.Metadata, err error) {
 	if err != nil {
 		return
 	}
-	m, err = metadata.New(doc.Find("script").Text())
+	scripts := doc.Find("script").Map(func(_ int, s *goquery.Selection) string {
+		return s.Text()
+	})
+	var script string
+	if script, err = metadata.FindStateScript(scripts); err == nil {
+		m, err = metadata.New(script)
+	}
+	var extractErr *metadata.ExtractError
+	if errors.As(err, &extractErr) {
+		a.logger.Debug().Str("step", string(extractErr.Step)).Str("snippet", extractErr.Snippet).Msg("metadata extraction failed")
+	}
 	return
 }
 
-func assembleRequestURL(profile *Profile, provider stats.Provider, season string) (string, error) {
-	requestURLBytes := bytes.NewBuffer([]byte{})
-	args := stats.UbiStatsURLParams{
-		ProfileID:   profile.ProfileID,
-		Aggregation: provider.AggregationType(),
-		View:        provider.ViewType(),
-		Season:      season,
+// assembleRequestURL returns the URL requesting stats for provider, using q for all filters.
+// A non-empty season overrides the seasons of q.
+func assembleRequestURL(profile *Profile, provider stats.Provider, season string, q *stats.StatsQuery) (string, error) {
+	q = q.ForProvider(provider)
+	if season != "" {
+		q.Seasons(strings.Split(season, ",")...)
 	}
-	if err := stats.UbiStatsURLTemplate.Execute(requestURLBytes, args); err != nil {
-		return "", err
-	}
-	return requestURLBytes.String(), nil
+	return q.URL(profile.ProfileID)
 }
 
 // GetStats retrieves statistics for a specific profile and season, loading the results into dst.
 // dst needs to implement stats.Provider, the preconfigured providers can be found in the stats package.
-func (a *R6API) GetStats(profile *Profile, season string, dst stats.Provider) error {
+// Providers with a seasonal view (e.g. stats.SummarizedStats, stats.OperatorStats) accept multiple comma-separated seasons (e.g. "Y8S1,Y8S2"),
+// in which case the stats are aggregated across all seasons and additionally provided per season in their Seasons field.
+// The request can be configured with opts, e.g. to restrict the requested game modes or team roles or to pass a complete stats.StatsQuery with WithQuery,
+// in which case season can be empty to use the seasons of the query instead.
+// If enriching map stats with bombsite stats fails for some maps, dst still contains all other results and the returned error combines all failures.
+func (a *R6API) GetStats(profile *Profile, season string, dst stats.Provider, opts ...StatsOption) error {
+	return a.getStats(profile, season, dst, newStatsOptions(opts))
+}
+
+func (a *R6API) getStats(profile *Profile, season string, dst stats.Provider, opts *statsOptions) error {
 	a.logger.Info().
 		Str("username", profile.Name).
 		Str("type", dst.AggregationType()).
 		Str("season", season).
 		Msg("getting stats")
-	requestURL, err := assembleRequestURL(profile, dst, season)
+	requestURL, err := assembleRequestURL(profile, dst, season, opts.query)
 	if err != nil {
 		return err
 	}
 
-	if err := a.requestAuthorized(requestURL, dst); err != nil {
+	if err := a.requestDecodedStats(profile, requestURL, dst, opts); err != nil {
 		return err
 	}
 
-	if mapStats, isMapStats := dst.(*stats.MapStats); isMapStats {
-		return a.enrichMapStats(mapStats, profile, season)
+	if mapStats, isMapStats := dst.(*stats.MapStats); isMapStats && opts.bombsites {
+		return a.enrichMapStats(mapStats, profile, season, opts)
 	}
 	return nil
 }
 
-// enrichMapStats adds bombsite stats to the map stats
-func (a *R6API) enrichMapStats(data *stats.MapStats, profile *Profile, season string) (err error) {
+// GetStatsRange retrieves statistics for a specific profile across multiple seasons, merging the results into dst.
+// Each season is requested separately, so this performs one request (plus map enrichment, if applicable) per season.
+// Duplicate seasons (ignoring case) are only requested once so that their stats are not counted twice.
+// dst needs to be a non-nil pointer implementing stats.Merger, which is the case for the summarized, operator, map and weapon stats providers.
+func (a *R6API) GetStatsRange(profile *Profile, seasons []string, dst stats.Merger, opts ...StatsOption) error {
+	if len(seasons) == 0 {
+		return errors.New("no seasons provided")
+	}
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Pointer || dstValue.IsNil() {
+		return fmt.Errorf("dst needs to be a non-nil pointer, got %T", dst)
+	}
+	o := newStatsOptions(opts)
+	for i, season := range uniqueSeasons(seasons) {
+		if i == 0 {
+			if err := a.getStats(profile, season, dst, o); err != nil {
+				return fmt.Errorf("could not get stats for season %s: %w", season, err)
+			}
+			continue
+		}
+		seasonStats := reflect.New(dstValue.Type().Elem()).Interface().(stats.Merger)
+		if err := a.getStats(profile, season, seasonStats, o); err != nil {
+			return fmt.Errorf("could not get stats for season %s: %w", season, err)
+		}
+		if err := dst.Merge(seasonStats); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uniqueSeasons returns seasons without duplicates (ignoring case), keeping the order of their first occurrence.
+func uniqueSeasons(seasons []string) []string {
+	seen := make(map[string]struct{}, len(seasons))
+	unique := make([]string, 0, len(seasons))
+	for _, season := range seasons {
+		key := strings.ToUpper(season)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, season)
+	}
+	return unique
+}
+
+// enrichMapStats adds bombsite stats to the map stats.
+// Since the bombsite response covers all game modes, every played map is only requested once.
+// Requests are performed concurrently, limited by opts.bombsiteConcurrency.
+// If some requests fail, the remaining maps are still enriched and the errors are returned combined.
+func (a *R6API) enrichMapStats(data *stats.MapStats, profile *Profile, season string, opts *statsOptions) error {
 	a.logger.Info().
 		Str("username", profile.Name).
 		Str("type", data.AggregationType()).
 		Str("season", season).
 		Msg("enriching map stats")
 	gameModes := []*map[string]stats.NamedMapStatDetails{data.All, data.Casual, data.Unranked, data.Ranked}
-	for i, gameMode := range gameModes {
+
+	mapNames := map[string]struct{}{}
+	for _, gameMode := range gameModes {
 		if gameMode == nil {
 			continue
 		}
 		for mapName, mapStats := range *gameMode {
-			if mapStats.MatchesPlayed == 0 {
-				continue
+			if mapPlayed(mapStats) {
+				mapNames[mapName] = struct{}{}
 			}
-			bombsiteStats := new(stats.BombsiteStats)
-			var baseURL string
-			baseURL, err = assembleRequestURL(profile, bombsiteStats, season)
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	semaphore := make(chan struct{}, opts.bombsiteConcurrency)
+	for mapName := range mapNames {
+		wg.Add(1)
+		go func(mapName string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			bombsiteStats, err := a.getBombsiteStats(profile, mapName, season, opts)
+
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
+				errs = append(errs, fmt.Errorf("could not get bombsite stats for map %s: %w", mapName, err))
 				return
 			}
-			requestURL := baseURL + "&maps=" + url.QueryEscape(mapName)
-			if err = a.requestAuthorized(requestURL, bombsiteStats); err != nil {
-				return
+			bombsiteGameModes := []*stats.BombsiteGamemodeStats{bombsiteStats.All, bombsiteStats.Casual, bombsiteStats.Unranked, bombsiteStats.Ranked}
+			for i, gameMode := range gameModes {
+				if gameMode == nil {
+					continue
+				}
+				s, ok := (*gameMode)[mapName]
+				if !ok {
+					continue
+				}
+				s.Bombsites = bombsiteGameModes[i]
+				(*gameMode)[mapName] = s
 			}
+		}(mapName)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
 
-			s := (*gameMode)[mapName]
-			switch i {
-			case 0:
-				s.Bombsites = bombsiteStats.All
-			case 1:
-				s.Bombsites = bombsiteStats.Casual
-			case 2:
-				s.Bombsites = bombsiteStats.Unranked
-			case 3:
-				s.Bombsites = bombsiteStats.Ranked
-			default:
-				panic("please check gameModes slice length and amend switch-case accordingly")
-			}
-			(*gameMode)[mapName] = s
+// getBombsiteStats retrieves the bombsite stats for a single map.
+func (a *R6API) getBombsiteStats(profile *Profile, mapName string, season string, opts *statsOptions) (*stats.BombsiteStats, error) {
+	bombsiteStats := new(stats.BombsiteStats)
+	requestURL, err := assembleRequestURL(profile, bombsiteStats, season, opts.query.Clone().Maps(mapName))
+	if err != nil {
+		return nil, err
+	}
+	if err = a.requestDecodedStats(profile, requestURL, bombsiteStats, opts); err != nil {
+		return nil, err
+	}
+	return bombsiteStats, nil
+}
+
+// requestDecodedStats performs the same as requestStats, applying the decode options of opts to dst and logging decode issues and skipped stats.
+func (a *R6API) requestDecodedStats(profile *Profile, url string, dst stats.Provider, opts *statsOptions) error {
+	decodable, isDecodable := dst.(stats.Decodable)
+	if isDecodable {
+		decodable.SetDecodeOptions(opts.query.DecodeOptions(opts.decode))
+	}
+	err := a.requestStats(profile, dst.AggregationType(), url, dst)
+	var statusErr *request.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusBadRequest && opts.query.HasDateRange() {
+		// the window of dates served by the API is undocumented, so ranges exceeding it are only detected by the API
+		err = fmt.Errorf("%w: rejected by the API: %w", stats.ErrInvalidDateRange, err)
+	}
+	if isDecodable {
+		for _, unknown := range decodable.UnknownVariants() {
+			a.logger.Warn().
+				Str("username", profile.Name).
+				Str("type", dst.AggregationType()).
+				Str("gameMode", string(unknown.GameMode)).
+				Str("teamRole", string(unknown.TeamRole)).
+				Str("variant", unknown.Type).
+				Msg("skipped stats of unknown type")
+		}
+		for _, issue := range decodable.DecodeIssues() {
+			a.logger.Warn().
+				Str("username", profile.Name).
+				Str("type", dst.AggregationType()).
+				Str("kind", string(issue.Kind)).
+				Str("path", issue.Path).
+				Str("detail", issue.Detail).
+				Msg("response does not match schema")
 		}
 	}
-	return
+	return err
+}
+
+// mapPlayed returns true if any matches were played on the map in any of the requested team roles.
+func mapPlayed(s stats.NamedMapStatDetails) bool {
+	return s.MatchesPlayed > 0 ||
+		(s.Attack != nil && s.Attack.MatchesPlayed > 0) ||
+		(s.Defence != nil && s.Defence.MatchesPlayed > 0)
 }
 
 // GetRankedHistory returns a list of stats for the last numSeasons past ranked seasons.
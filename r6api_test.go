@@ -0,0 +1,809 @@
+package r6api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stnokott/r6api"
+	"github.com/stnokott/r6api/cache"
+	"github.com/stnokott/r6api/r6apitest"
+	"github.com/stnokott/r6api/types/metadata"
+	"github.com/stnokott/r6api/types/stats"
+)
+
+func newTestAPI(t *testing.T) (*r6api.R6API, *r6apitest.Server) {
+	t.Helper()
+	srv := r6apitest.NewServer()
+	t.Cleanup(srv.Close)
+	a := r6api.NewR6API(
+		"test@example.com",
+		"password",
+		zerolog.Nop(),
+		r6api.WithHTTPClient(srv.Client()),
+		r6api.WithTicketFile(""),
+	)
+	return a, srv
+}
+
+// readFixture returns the content of a fixture of the r6apitest fake server.
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("r6apitest", "testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestResolveUser(t *testing.T) {
+	a, _ := newTestAPI(t)
+
+	profile, err := a.ResolveUser("TestUser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.ProfileID != r6apitest.ProfileID {
+		t.Errorf("want profile ID %s, got %s", r6apitest.ProfileID, profile.ProfileID)
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	a, srv := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	tests := []struct {
+		name         string
+		provider     stats.Provider
+		wantRequests int
+		check        func(t *testing.T, p stats.Provider)
+	}{
+		{
+			name:         "summary",
+			provider:     new(stats.SummarizedStats),
+			wantRequests: 1,
+			check: func(t *testing.T, p stats.Provider) {
+				s := p.(*stats.SummarizedStats)
+				if s.Ranked == nil || s.Ranked.All == nil || s.Ranked.All.Kills != 180 {
+					t.Errorf("unexpected ranked stats: %+v", s.Ranked)
+				}
+			},
+		},
+		{
+			name:         "operators",
+			provider:     new(stats.OperatorStats),
+			wantRequests: 1,
+			check: func(t *testing.T, p stats.Provider) {
+				s := p.(*stats.OperatorStats)
+				if _, ok := s.All.Attack["Ash"]; !ok {
+					t.Error("missing operator Ash")
+				}
+			},
+		},
+		{
+			name:     "maps with bombsites",
+			provider: new(stats.MapStats),
+			// two played maps, each requested once for all game modes
+			wantRequests: 1 + 2,
+			check: func(t *testing.T, p stats.Provider) {
+				s := p.(*stats.MapStats)
+				clubhouse, ok := (*s.Ranked)["CLUBHOUSE"]
+				if !ok {
+					t.Fatal("missing map CLUBHOUSE")
+				}
+				if clubhouse.Attack == nil || clubhouse.Defence == nil {
+					t.Error("missing team role stats")
+				}
+				if clubhouse.Bombsites == nil || len(clubhouse.Bombsites.All) != 2 {
+					t.Errorf("unexpected bombsites: %+v", clubhouse.Bombsites)
+				}
+				if oregon := (*s.Ranked)["OREGON"]; oregon.Bombsites != nil {
+					t.Error("unplayed map should not be enriched")
+				}
+			},
+		},
+		{
+			name:         "weapons",
+			provider:     new(stats.WeaponStats),
+			wantRequests: 1,
+			check: func(t *testing.T, p stats.Provider) {
+				s := p.(*stats.WeaponStats)
+				if _, ok := s.All.Attack.PrimaryWeapons["Assault Rifle"]["R4-C"]; !ok {
+					t.Error("missing weapon R4-C")
+				}
+			},
+		},
+		{
+			name:         "moving trend",
+			provider:     new(stats.MovingTrendStats),
+			wantRequests: 1,
+			check: func(t *testing.T, p stats.Provider) {
+				s := p.(*stats.MovingTrendStats)
+				if s.All.All == nil || len(s.All.All.KillsPerRound.Actuals) != 5 {
+					t.Errorf("unexpected moving trend: %+v", s.All.All)
+				}
+				if kpr := s.All.All.KillsPerRound; kpr.MovingPoints != 5 || kpr.Actuals[0].Position != 1 || kpr.Actuals[4].Value != 0.8 {
+					t.Errorf("unexpected kills per round: %+v", kpr)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := srv.Requests(r6apitest.PlayerStats)
+			if err := a.GetStats(profile, "Y8S2", tt.provider); err != nil {
+				t.Fatal(err)
+			}
+			if n := srv.Requests(r6apitest.PlayerStats) - before; n != tt.wantRequests {
+				t.Errorf("want %d stats requests, got %d", tt.wantRequests, n)
+			}
+			tt.check(t, tt.provider)
+		})
+	}
+}
+
+// valueMerger implements stats.Merger without being a pointer, which GetStatsRange cannot instantiate.
+type valueMerger struct{}
+
+func (valueMerger) UnmarshalJSON([]byte) error { return nil }
+func (valueMerger) AggregationType() string    { return "summary" }
+func (valueMerger) ViewType() string           { return "seasonal" }
+func (valueMerger) Merge(stats.Provider) error { return nil }
+
+func TestGetStatsRange(t *testing.T) {
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	tests := []struct {
+		name string
+		// newStats returns a new provider to fill with a single season and a range of seasons, respectively
+		newStats func() stats.Merger
+		kills    func(m stats.Merger) int
+		// requestsPerSeason is the number of stats requests per season, including map enrichment
+		requestsPerSeason int
+	}{
+		{
+			name:              "summary",
+			newStats:          func() stats.Merger { return new(stats.SummarizedStats) },
+			kills:             func(m stats.Merger) int { return m.(*stats.SummarizedStats).Ranked.All.Kills },
+			requestsPerSeason: 1,
+		},
+		{
+			name:              "operators",
+			newStats:          func() stats.Merger { return new(stats.OperatorStats) },
+			kills:             func(m stats.Merger) int { return m.(*stats.OperatorStats).All.Attack["Ash"].Kills },
+			requestsPerSeason: 1,
+		},
+		{
+			name:     "maps",
+			newStats: func() stats.Merger { return new(stats.MapStats) },
+			kills: func(m stats.Merger) int {
+				clubhouse := (*m.(*stats.MapStats).Ranked)["CLUBHOUSE"]
+				kills := clubhouse.Kills
+				for _, bombsite := range clubhouse.Bombsites.All {
+					kills += bombsite.Kills
+				}
+				return kills
+			},
+			requestsPerSeason: 1 + 2,
+		},
+		{
+			name:     "weapons",
+			newStats: func() stats.Merger { return new(stats.WeaponStats) },
+			kills: func(m stats.Merger) int {
+				return m.(*stats.WeaponStats).All.Attack.PrimaryWeapons["Assault Rifle"]["R4-C"].Kills
+			},
+			requestsPerSeason: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, srv := newTestAPI(t)
+			single := tt.newStats()
+			if err := a.GetStats(profile, "Y8S1", single); err != nil {
+				t.Fatal(err)
+			}
+
+			before := srv.Requests(r6apitest.PlayerStats)
+			merged := tt.newStats()
+			if err := a.GetStatsRange(profile, []string{"Y8S1", "Y8S2"}, merged); err != nil {
+				t.Fatal(err)
+			}
+			if n := srv.Requests(r6apitest.PlayerStats) - before; n != 2*tt.requestsPerSeason {
+				t.Errorf("want %d stats requests, got %d", 2*tt.requestsPerSeason, n)
+			}
+			// the fake server responds with the same stats for every season
+			if want, got := 2*tt.kills(single), tt.kills(merged); want != got {
+				t.Errorf("want %d kills, got %d", want, got)
+			}
+		})
+	}
+
+	t.Run("duplicate seasons", func(t *testing.T) {
+		a, srv := newTestAPI(t)
+		single := new(stats.SummarizedStats)
+		if err := a.GetStats(profile, "Y8S1", single); err != nil {
+			t.Fatal(err)
+		}
+		before := srv.Requests(r6apitest.PlayerStats)
+		merged := new(stats.SummarizedStats)
+		if err := a.GetStatsRange(profile, []string{"Y8S1", "y8s1", "Y8S1"}, merged); err != nil {
+			t.Fatal(err)
+		}
+		if n := srv.Requests(r6apitest.PlayerStats) - before; n != 1 {
+			t.Errorf("want 1 stats request, got %d", n)
+		}
+		if merged.Ranked.All.Kills != single.Ranked.All.Kills {
+			t.Errorf("duplicate seasons should not be counted twice, want %d kills, got %d", single.Ranked.All.Kills, merged.Ranked.All.Kills)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		a, _ := newTestAPI(t)
+		if err := a.GetStatsRange(profile, nil, new(stats.SummarizedStats)); err == nil {
+			t.Error("expected error without seasons")
+		}
+		if err := a.GetStatsRange(profile, []string{"Y8S1", "Y8S2"}, valueMerger{}); err == nil {
+			t.Error("expected error for non-pointer dst")
+		}
+		if err := a.GetStatsRange(profile, []string{"Y8S1", "Y8S2"}, (*stats.SummarizedStats)(nil)); err == nil {
+			t.Error("expected error for nil dst")
+		}
+	})
+}
+
+func TestGetStatsGameModesTeamRoles(t *testing.T) {
+	a, srv := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	s := new(stats.MapStats)
+	err := a.GetStats(profile, "Y8S2", s,
+		r6api.WithGameModes(stats.RANKED, stats.CASUAL),
+		r6api.WithTeamRoles(stats.ATTACKER, stats.DEFENDER),
+		r6api.WithBombsites(false),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := srv.LastQuery(r6apitest.PlayerStats)
+	if query.Get("gameMode") != "ranked,casual" || query.Get("teamRole") != "Attacker,Defender" {
+		t.Errorf("unexpected query %v", query)
+	}
+	clubhouse := (*s.Ranked)["CLUBHOUSE"]
+	if clubhouse.Attack == nil || clubhouse.Defence == nil || clubhouse.Bombsites != nil {
+		t.Errorf("unexpected map stats %+v", clubhouse)
+	}
+}
+
+func TestGetStatsMapPlayedInOneTeamRole(t *testing.T) {
+	a, srv := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	// OREGON has no matches in the all-roles entry, but was played in attack
+	var response map[string]any
+	if err := json.Unmarshal(readFixture(t, "maps.json"), &response); err != nil {
+		t.Fatal(err)
+	}
+	profileData := response["profileData"].(map[string]any)[r6apitest.ProfileID].(map[string]any)
+	ranked := profileData["platforms"].(map[string]any)["PC"].(map[string]any)["gameModes"].(map[string]any)["ranked"].(map[string]any)
+	for _, entry := range ranked["teamRoles"].(map[string]any)["Attacker"].([]any) {
+		if entry := entry.(map[string]any); entry["statsDetail"] == "OREGON" {
+			entry["matchesPlayed"] = 2
+		}
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetStatsFixture("maps", data)
+
+	s := new(stats.MapStats)
+	if err = a.GetStats(profile, "Y8S2", s); err != nil {
+		t.Fatal(err)
+	}
+	if oregon := (*s.Ranked)["OREGON"]; oregon.Bombsites == nil {
+		t.Error("map played in one team role should be enriched")
+	}
+	if n := srv.Requests(r6apitest.PlayerStats); n != 1+3 {
+		t.Errorf("want %d stats requests, got %d", 1+3, n)
+	}
+}
+
+// bombsiteTransport records the bombsite requests passing through it, delaying each to make concurrent requests overlap.
+type bombsiteTransport struct {
+	base http.RoundTripper
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	maps        []string
+}
+
+func (t *bombsiteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.URL.Query().Get("aggregation") != "bombsites" {
+		return t.base.RoundTrip(r)
+	}
+	t.mu.Lock()
+	t.inFlight++
+	if t.inFlight > t.maxInFlight {
+		t.maxInFlight = t.inFlight
+	}
+	t.maps = append(t.maps, r.URL.Query().Get("maps"))
+	t.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+	defer func() {
+		t.mu.Lock()
+		t.inFlight--
+		t.mu.Unlock()
+	}()
+	return t.base.RoundTrip(r)
+}
+
+// allMapsPlayedFixture returns the maps fixture with matches on every map, OREGON being unplayed in the default fixture.
+func allMapsPlayedFixture(t *testing.T) []byte {
+	t.Helper()
+	return bytes.ReplaceAll(readFixture(t, "maps.json"), []byte(`"matchesPlayed": 0`), []byte(`"matchesPlayed": 1`))
+}
+
+// newBombsiteTestAPI returns an API whose map stats contain three played maps, recording bombsite requests in the returned transport.
+func newBombsiteTestAPI(t *testing.T) (*r6api.R6API, *r6apitest.Server, *bombsiteTransport) {
+	t.Helper()
+	srv := r6apitest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.SetStatsFixture("maps", allMapsPlayedFixture(t))
+
+	transport := &bombsiteTransport{base: srv.Client().Transport}
+	a := r6api.NewR6API("test@example.com", "password", zerolog.Nop(),
+		r6api.WithHTTPClient(&http.Client{Transport: transport}), r6api.WithTicketFile(""))
+	return a, srv, transport
+}
+
+func TestEnrichMapStats(t *testing.T) {
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	t.Run("once per map", func(t *testing.T) {
+		a, _, transport := newBombsiteTestAPI(t)
+		s := new(stats.MapStats)
+		if err := a.GetStats(profile, "Y8S2", s); err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(transport.maps)
+		if want := []string{"BANK", "CLUBHOUSE", "OREGON"}; !reflect.DeepEqual(transport.maps, want) {
+			t.Errorf("want bombsite requests for %v, got %v", want, transport.maps)
+		}
+		for _, gameMode := range []*map[string]stats.NamedMapStatDetails{s.All, s.Ranked} {
+			for name, m := range *gameMode {
+				if m.Bombsites == nil {
+					t.Errorf("map %s not enriched", name)
+				}
+			}
+		}
+	})
+
+	t.Run("concurrency", func(t *testing.T) {
+		for _, n := range []int{1, 2} {
+			a, _, transport := newBombsiteTestAPI(t)
+			if err := a.GetStats(profile, "Y8S2", new(stats.MapStats), r6api.WithBombsiteConcurrency(n)); err != nil {
+				t.Fatal(err)
+			}
+			if transport.maxInFlight > n {
+				t.Errorf("want at most %d concurrent bombsite requests, got %d", n, transport.maxInFlight)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		a, srv, transport := newBombsiteTestAPI(t)
+		s := new(stats.MapStats)
+		if err := a.GetStats(profile, "Y8S2", s, r6api.WithBombsites(false)); err != nil {
+			t.Fatal(err)
+		}
+		if len(transport.maps) != 0 || srv.Requests(r6apitest.PlayerStats) != 1 {
+			t.Errorf("want no bombsite requests, got %v", transport.maps)
+		}
+		if clubhouse := (*s.Ranked)["CLUBHOUSE"]; clubhouse.Bombsites != nil {
+			t.Error("map should not be enriched")
+		}
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		a, srv, _ := newBombsiteTestAPI(t)
+		// the map request succeeds, the first bombsite request fails
+		srv.Script(r6apitest.PlayerStats,
+			r6apitest.Response{Body: allMapsPlayedFixture(t)},
+			r6apitest.Error(http.StatusInternalServerError, "internal error"),
+		)
+		s := new(stats.MapStats)
+		err := a.GetStats(profile, "Y8S2", s, r6api.WithBombsiteConcurrency(1))
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		enriched := 0
+		for name, m := range *s.Ranked {
+			if m.Bombsites != nil {
+				enriched++
+			} else if !strings.Contains(err.Error(), name) {
+				t.Errorf("error should name map %s: %v", name, err)
+			}
+		}
+		if enriched != 2 {
+			t.Errorf("want the remaining 2 maps enriched, got %d", enriched)
+		}
+	})
+}
+
+func TestConcurrentLogin(t *testing.T) {
+	a, srv := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- a.GetStats(profile, "Y8S2", new(stats.SummarizedStats))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := srv.Requests(r6apitest.Sessions); n != 1 {
+		t.Errorf("want 1 login for concurrent requests, got %d", n)
+	}
+}
+
+func TestGetStatsCache(t *testing.T) {
+	srv := r6apitest.NewServer()
+	t.Cleanup(srv.Close)
+	a := r6api.NewR6API("test@example.com", "password", zerolog.Nop(),
+		r6api.WithHTTPClient(srv.Client()), r6api.WithTicketFile(""), r6api.WithCache(cache.New(cache.NewMemory(10), time.Minute)))
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := new(stats.SummarizedStats)
+			if err := a.GetStats(profile, "Y8S2", s); err != nil {
+				t.Error(err)
+			} else if s.Ranked == nil {
+				t.Error("missing ranked stats")
+			}
+		}()
+	}
+	wg.Wait()
+	if n := srv.Requests(r6apitest.PlayerStats); n != 1 {
+		t.Errorf("want 1 stats request for concurrent identical requests, got %d", n)
+	}
+
+	if err := a.InvalidateCache(profile); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.GetStats(profile, "Y8S2", new(stats.SummarizedStats)); err != nil {
+		t.Fatal(err)
+	}
+	if n := srv.Requests(r6apitest.PlayerStats); n != 2 {
+		t.Errorf("want stats to be requested again after invalidation, got %d requests", n)
+	}
+}
+
+func TestGetRankedHistory(t *testing.T) {
+	a, _ := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	history, err := a.GetRankedHistory(profile, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[1].SeasonID != 30 || history[1].MMR != 3478 {
+		t.Errorf("unexpected history: %+v", history)
+	}
+}
+
+func TestGetMetadata(t *testing.T) {
+	a, _ := newTestAPI(t)
+
+	m, err := a.GetMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slug := m.SeasonSlugFromID(30); slug != "Y8S2" {
+		t.Errorf("want slug Y8S2, got '%s'", slug)
+	}
+
+	// the cached metadata must not be affected by callers
+	last := len(m.Seasons) - 1
+	m.Seasons[0].Slug = "modified"
+	m.Seasons[last].Ranks[0].MinMMR = -1
+	if m, _ = a.GetMetadata(); m.Seasons[0].Slug == "modified" || m.Seasons[last].Ranks[0].MinMMR == -1 {
+		t.Error("modifying result should not affect cached metadata")
+	}
+}
+
+func TestGetMetadataCache(t *testing.T) {
+	t.Run("ttl", func(t *testing.T) {
+		a, srv := newTestAPI(t)
+		for i := 0; i < 2; i++ {
+			if _, err := a.GetMetadata(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if n := srv.Requests(r6apitest.Glossary); n != 1 {
+			t.Errorf("want 1 request, got %d", n)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		srv := r6apitest.NewServer()
+		t.Cleanup(srv.Close)
+		a := r6api.NewR6API("test@example.com", "password", zerolog.Nop(),
+			r6api.WithHTTPClient(srv.Client()), r6api.WithTicketFile(""), r6api.WithMetadataTTL(0))
+		for i := 0; i < 2; i++ {
+			if _, err := a.GetMetadata(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if n := srv.Requests(r6apitest.Glossary); n != 2 {
+			t.Errorf("want 2 requests, got %d", n)
+		}
+	})
+
+	t.Run("snapshot file", func(t *testing.T) {
+		srv := r6apitest.NewServer()
+		t.Cleanup(srv.Close)
+		path := filepath.Join(t.TempDir(), "metadata.json")
+		newAPI := func() *r6api.R6API {
+			return r6api.NewR6API("test@example.com", "password", zerolog.Nop(),
+				r6api.WithHTTPClient(srv.Client()), r6api.WithTicketFile(""), r6api.WithMetadataFile(path))
+		}
+
+		fetched, err := newAPI().GetMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := newAPI().GetMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := srv.Requests(r6apitest.Glossary); n != 1 {
+			t.Errorf("want 1 request, got %d", n)
+		}
+		if !loaded.FetchedAt.Equal(fetched.FetchedAt) || loaded.SeasonSlugFromID(30) != "Y8S2" {
+			t.Errorf("unexpected snapshot: %+v", loaded)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		a, srv := newTestAPI(t)
+		srv.Script(r6apitest.Glossary, r6apitest.Error(http.StatusServiceUnavailable, "unavailable"))
+		m, err := a.GetMetadata()
+		if !errors.Is(err, r6api.ErrStaleMetadata) {
+			t.Fatalf("want ErrStaleMetadata, got %v", err)
+		}
+		if !m.FetchedAt.Equal(metadata.Fallback().FetchedAt) || m.SeasonSlugFromID(30) != "Y8S2" {
+			t.Errorf("want embedded fallback, got %+v", m)
+		}
+		// fetching is not retried immediately, but the metadata stays stale
+		if _, err = a.GetMetadata(); !errors.Is(err, r6api.ErrStaleMetadata) {
+			t.Fatalf("want ErrStaleMetadata, got %v", err)
+		}
+		if n := srv.Requests(r6apitest.Glossary); n != 1 {
+			t.Errorf("want 1 request, got %d", n)
+		}
+	})
+}
+
+func TestScriptedFailures(t *testing.T) {
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	t.Run("too many requests", func(t *testing.T) {
+		a, srv := newTestAPI(t)
+		srv.Script(r6apitest.PlayerStats, r6apitest.TooManyRequests())
+		if err := a.GetStats(profile, "Y8S2", new(stats.SummarizedStats)); err == nil {
+			t.Error("expected error")
+		}
+		if err := a.GetStats(profile, "Y8S2", new(stats.SummarizedStats)); err != nil {
+			t.Errorf("expected recovery after scripted response, got %v", err)
+		}
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		a, srv := newTestAPI(t)
+		srv.Script(r6apitest.PlayerStats, r6apitest.MalformedJSON())
+		if err := a.GetStats(profile, "Y8S2", new(stats.SummarizedStats)); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("expired ticket", func(t *testing.T) {
+		a, srv := newTestAPI(t)
+		srv.Script(r6apitest.Sessions, r6apitest.ExpiredTicket())
+		if err := a.GetStats(profile, "Y8S2", new(stats.SummarizedStats)); err == nil {
+			t.Error("expected error for expired ticket")
+		}
+		if err := a.GetStats(profile, "Y8S2", new(stats.SummarizedStats)); err != nil {
+			t.Errorf("expected re-login, got %v", err)
+		}
+		if n := srv.Requests(r6apitest.Sessions); n != 2 {
+			t.Errorf("want 2 logins, got %d", n)
+		}
+	})
+}
+
+func TestGetStatsRawResponse(t *testing.T) {
+	a, _ := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	s := new(stats.OperatorStats)
+	if err := a.GetStats(profile, "Y8S2", s, r6api.WithRawResponse()); err != nil {
+		t.Fatal(err)
+	}
+	raw := s.Raw()
+	if len(raw) != 1 {
+		t.Fatalf("want 1 raw response, got %d", len(raw))
+	}
+
+	reparsed := new(stats.OperatorStats)
+	if err := json.Unmarshal(raw[0], reparsed); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reparsed.All.All["Ash"].Kills, s.All.All["Ash"].Kills; got != want {
+		t.Errorf("want %d kills after decoding raw response again, got %d", want, got)
+	}
+	if reparsed.Raw() != nil {
+		t.Error("raw response should only be kept if requested")
+	}
+}
+
+func TestGetStatsQuery(t *testing.T) {
+	a, srv := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	q := stats.NewStatsQuery().Seasons("Y8S1", "Y8S2").GameModes(stats.RANKED).TeamRoles(stats.ATTACKER).Maps("CLUBHOUSE")
+	if err := a.GetStats(profile, "", new(stats.OperatorStats), r6api.WithQuery(q)); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"aggregation": "operators",
+		"view":        "seasonal",
+		"seasons":     "Y8S1,Y8S2",
+		"gameMode":    "ranked",
+		"teamRole":    "Attacker",
+		"maps":        "CLUBHOUSE",
+	}
+	query := srv.LastQuery(r6apitest.PlayerStats)
+	for k, v := range want {
+		if got := query.Get(k); got != v {
+			t.Errorf("%s: want '%s', got '%s'", k, v, got)
+		}
+	}
+
+	// season argument takes precedence, options modify a copy of the query
+	if err := a.GetStats(profile, "Y7S4", new(stats.OperatorStats), r6api.WithQuery(q), r6api.WithGameModes(stats.CASUAL)); err != nil {
+		t.Fatal(err)
+	}
+	query = srv.LastQuery(r6apitest.PlayerStats)
+	if query.Get("seasons") != "Y7S4" || query.Get("gameMode") != "casual" {
+		t.Errorf("unexpected query %v", query)
+	}
+	if err := a.GetStats(profile, "", new(stats.OperatorStats), r6api.WithQuery(q)); err != nil {
+		t.Fatal(err)
+	}
+	if query = srv.LastQuery(r6apitest.PlayerStats); query.Get("gameMode") != "ranked" {
+		t.Errorf("options should not modify the query passed to WithQuery, got %v", query)
+	}
+}
+
+func TestGetStatsMapsOperators(t *testing.T) {
+	a, srv := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	if err := a.GetStats(profile, "Y8S2", new(stats.OperatorStats), r6api.WithMaps("CLUBHOUSE", "KAFE DOSTOYEVSKY")); err != nil {
+		t.Fatal(err)
+	}
+	query := srv.LastQuery(r6apitest.PlayerStats)
+	if query.Get("maps") != "CLUBHOUSE,KAFE DOSTOYEVSKY" || query.Has("operators") {
+		t.Errorf("unexpected query %v", query)
+	}
+
+	if err := a.GetStats(profile, "Y8S2", new(stats.MapStats), r6api.WithOperators("Ash"), r6api.WithBombsites(false)); err != nil {
+		t.Fatal(err)
+	}
+	query = srv.LastQuery(r6apitest.PlayerStats)
+	if query.Get("operators") != "Ash" || query.Has("maps") {
+		t.Errorf("unexpected query %v", query)
+	}
+}
+
+func TestGetStatsView(t *testing.T) {
+	a, srv := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	if err := a.GetStats(profile, "Y8S2", new(stats.WeaponStats), r6api.WithView("seasonal")); err != nil {
+		t.Fatal(err)
+	}
+	if query := srv.LastQuery(r6apitest.PlayerStats); query.Get("view") != "seasonal" || query.Get("aggregation") != "weapons" {
+		t.Errorf("unexpected query %v", query)
+	}
+}
+
+func TestGetStatsDateRange(t *testing.T) {
+	a, srv := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+
+	monday, sunday := stats.PreviousWeek(time.Now())
+	if err := a.GetStats(profile, "", new(stats.SummarizedStats), r6api.WithDateRange(monday, sunday)); err != nil {
+		t.Fatal(err)
+	}
+	query := srv.LastQuery(r6apitest.PlayerStats)
+	if query.Get("startDate") != monday.Format("20060102") || query.Get("endDate") != sunday.Format("20060102") || query.Has("seasons") {
+		t.Errorf("unexpected query %v", query)
+	}
+
+	before := srv.Requests(r6apitest.PlayerStats)
+	err := a.GetStats(profile, "Y8S2", new(stats.SummarizedStats), r6api.WithDateRange(monday, sunday))
+	if !errors.Is(err, stats.ErrInvalidDateRange) {
+		t.Errorf("want ErrInvalidDateRange, got %v", err)
+	}
+	if srv.Requests(r6apitest.PlayerStats) != before {
+		t.Error("invalid query should not be requested")
+	}
+
+	// ranges exceeding the window served by the API are only rejected by the API
+	srv.Script(r6apitest.PlayerStats, r6apitest.Error(http.StatusBadRequest, "invalid date range"))
+	start := time.Date(2015, 12, 1, 0, 0, 0, 0, time.UTC)
+	err = a.GetStats(profile, "", new(stats.SummarizedStats), r6api.WithDateRange(start, sunday))
+	if !errors.Is(err, stats.ErrInvalidDateRange) {
+		t.Errorf("want ErrInvalidDateRange for rejected range, got %v", err)
+	}
+	srv.Script(r6apitest.PlayerStats, r6apitest.Error(http.StatusBadRequest, "invalid season"))
+	if err = a.GetStats(profile, "Y8S2", new(stats.SummarizedStats)); err == nil || errors.Is(err, stats.ErrInvalidDateRange) {
+		t.Errorf("want other error for query without date range, got %v", err)
+	}
+}
+
+func TestGetStatsTrendType(t *testing.T) {
+	a, srv := newTestAPI(t)
+	profile := &r6api.Profile{Name: "TestUser", ProfileID: r6apitest.ProfileID}
+	srv.SetStatsFixture("movingpoint", []byte(`{"userId":"`+r6apitest.ProfileID+`","profileData":{"`+r6apitest.ProfileID+`":{"platforms":{"PC":{"gameModes":{"all":{"type":"Team roles","teamRoles":{"all":[`+
+		`{"type":"Moving Point Average Trend","statsType":"movingpoint","movingPoints":2,"killsPerRound":{"actuals":{"20230703":0.5,"20230704":0.7},"trend":{"20230703":0.5,"20230704":0.6}}}]}}}}}}}}`))
+
+	s := new(stats.MovingTrendStats)
+	if err := a.GetStats(profile, "", s, r6api.WithTrendType(stats.TrendByDay)); err != nil {
+		t.Fatal(err)
+	}
+	if query := srv.LastQuery(r6apitest.PlayerStats); query.Get("trendType") != string(stats.TrendByDay) {
+		t.Errorf("unexpected query %v", query)
+	}
+	actuals := s.All.All.KillsPerRound.Actuals
+	if want := time.Date(2023, 7, 4, 0, 0, 0, 0, time.UTC); len(actuals) != 2 || actuals[1].Date == nil || !actuals[1].Date.Equal(want) {
+		t.Errorf("want second point dated %s, got %+v", want, actuals)
+	}
+
+	// without trend type, the same positions are match numbers
+	s = new(stats.MovingTrendStats)
+	if err := a.GetStats(profile, "", s); err != nil {
+		t.Fatal(err)
+	}
+	if point := s.All.All.KillsPerRound.Actuals[0]; point.Date != nil || point.Position != 20230703 {
+		t.Errorf("unexpected point %+v", point)
+	}
+}
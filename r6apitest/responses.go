@@ -0,0 +1,49 @@
+package r6apitest
+
+import (
+	"net/http"
+	"time"
+)
+
+// Response is a scripted response, see Server.Script.
+type Response struct {
+	StatusCode int // defaults to 200
+	Header     http.Header
+	Body       []byte
+
+	// ticketExpiration makes the server issue a ticket with this expiration as body.
+	ticketExpiration *time.Time
+}
+
+// TooManyRequests responds with status 429, as Ubisoft does when rate-limiting.
+func TooManyRequests() Response {
+	return Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"1"}},
+		Body:       ubiError(1102, "Too many calls per profile"),
+	}
+}
+
+// MalformedJSON responds with status 200, but a truncated JSON body.
+func MalformedJSON() Response {
+	return Response{
+		Body: []byte(`{"profileData": {"`),
+	}
+}
+
+// Error responds with statusCode and a Ubisoft error body containing message.
+func Error(statusCode int, message string) Response {
+	return Response{
+		StatusCode: statusCode,
+		Body:       ubiError(statusCode, message),
+	}
+}
+
+// ExpiredTicket responds to a login with a ticket which is already expired, forcing clients to log in again.
+// Only applicable to the Sessions endpoint.
+func ExpiredTicket() Response {
+	expiration := time.Now().Add(-time.Hour)
+	return Response{
+		ticketExpiration: &expiration,
+	}
+}
@@ -0,0 +1,313 @@
+// Package r6apitest provides a fake implementation of the Ubisoft APIs used by r6api, serving fixtures.
+// The fixtures are hand-written to match the structure of real responses, their values are made up.
+// It can be used to test code depending on r6api without network access or Ubisoft credentials:
+//
+//	srv := r6apitest.NewServer()
+//	defer srv.Close()
+//	a := r6api.NewR6API("email", "password", logger, r6api.WithHTTPClient(srv.Client()), r6api.WithTicketFile(""))
+package r6apitest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed testdata
+var fixtureFS embed.FS
+
+// ProfileID is the profile ID of every user resolved by the server and used in the default fixtures.
+const ProfileID = "00000000-0000-0000-0000-000000000001"
+
+// Endpoint identifies one of the faked Ubisoft API endpoints.
+type Endpoint string
+
+const (
+	Sessions     Endpoint = "sessions"     // login, i.e. ticket creation
+	Profiles     Endpoint = "profiles"     // username resolution
+	PlayerStats  Endpoint = "playerstats"  // stats of all aggregation types
+	SkillRecords Endpoint = "skillrecords" // ranked history
+	Glossary     Endpoint = "glossary"     // metadata HTML page
+)
+
+// Server is a fake Ubisoft API.
+// By default, it serves fixtures for every endpoint, issues valid tickets and rejects requests with unknown or expired tickets.
+// Its behaviour can be scripted with Script.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	fixtures      map[Endpoint][]byte
+	statsFixtures map[string][]byte
+	scripts       map[Endpoint][]Response
+	requests      map[Endpoint]int
+	lastQueries   map[Endpoint]url.Values
+	tickets       map[string]time.Time
+}
+
+// NewServer starts a new fake server serving the default fixtures.
+// It should be closed after use.
+func NewServer() *Server {
+	s := &Server{
+		fixtures: map[Endpoint][]byte{
+			SkillRecords: mustReadFixture("skill_records.json"),
+			Glossary:     mustReadFixture("glossary.html"),
+		},
+		statsFixtures: map[string][]byte{},
+		scripts:       map[Endpoint][]Response{},
+		requests:      map[Endpoint]int{},
+		lastQueries:   map[Endpoint]url.Values{},
+		tickets:       map[string]time.Time{},
+	}
+	for _, aggregation := range []string{"summary", "operators", "maps", "bombsites", "weapons", "movingpoint"} {
+		s.statsFixtures[aggregation] = mustReadFixture(aggregation + ".json")
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func mustReadFixture(name string) []byte {
+	data, err := fixtureFS.ReadFile("testdata/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("missing fixture %s: %v", name, err))
+	}
+	return data
+}
+
+// Client returns an HTTP client which redirects all requests (regardless of their host) to this server.
+func (s *Server) Client() *http.Client {
+	target, _ := url.Parse(s.URL)
+	return &http.Client{
+		Transport: &redirectTransport{
+			target: target,
+			base:   s.Server.Client().Transport,
+		},
+	}
+}
+
+type redirectTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = t.target.Host
+	return t.base.RoundTrip(r)
+}
+
+// SetFixture replaces the body served for e.
+// For PlayerStats, use SetStatsFixture instead.
+// Sessions and Profiles are generated dynamically unless a fixture is set.
+func (s *Server) SetFixture(e Endpoint, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures[e] = body
+}
+
+// SetStatsFixture replaces the body served for stats requests with the provided aggregation type (e.g. "summary").
+func (s *Server) SetStatsFixture(aggregation string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statsFixtures[aggregation] = body
+}
+
+// Script queues responses for e, which are served in order for the next requests to e.
+// Afterwards, the server falls back to its default behaviour.
+func (s *Server) Script(e Endpoint, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[e] = append(s.scripts[e], responses...)
+}
+
+// LastQuery returns the query parameters of the last request received for e, nil if none was received yet.
+func (s *Server) LastQuery(e Endpoint) url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastQueries[e]
+}
+
+// Requests returns the number of requests received for e so far.
+func (s *Server) Requests(e Endpoint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[e]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	e, ok := endpointFromPath(r.URL.Path)
+	if !ok {
+		writeUbiError(w, http.StatusNotFound, 404, "unknown endpoint "+r.URL.Path)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[e]++
+	s.lastQueries[e] = r.URL.Query()
+
+	if scripted := s.scripts[e]; len(scripted) > 0 {
+		s.scripts[e] = scripted[1:]
+		s.serveScripted(w, scripted[0])
+		return
+	}
+
+	switch e {
+	case Sessions:
+		s.handleSessions(w, r)
+	case Profiles:
+		if s.authorize(w, r) {
+			s.handleProfiles(w, r)
+		}
+	case PlayerStats:
+		if s.authorize(w, r) {
+			s.handlePlayerStats(w, r)
+		}
+	case SkillRecords:
+		if s.authorize(w, r) {
+			writeJSON(w, s.fixtures[SkillRecords])
+		}
+	case Glossary:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(s.fixtures[Glossary])
+	}
+}
+
+func endpointFromPath(path string) (Endpoint, bool) {
+	switch {
+	case path == "/v3/profiles/sessions":
+		return Sessions, true
+	case path == "/v3/profiles":
+		return Profiles, true
+	case strings.HasPrefix(path, "/v1/users/") && strings.HasSuffix(path, "/playerstats"):
+		return PlayerStats, true
+	case strings.HasSuffix(path, "/r6karma/player_skill_records"):
+		return SkillRecords, true
+	case strings.Contains(path, "/stats/glossary/"):
+		return Glossary, true
+	default:
+		return "", false
+	}
+}
+
+func (s *Server) serveScripted(w http.ResponseWriter, resp Response) {
+	body := resp.Body
+	if resp.ticketExpiration != nil {
+		body = s.issueTicket(*resp.ticketExpiration)
+	}
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	statusCode := resp.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
+
+// authorize checks if r contains a ticket issued by this server which has not expired yet, writing an error response if not.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "ubi_v1 t=")
+	expiration, ok := s.tickets[token]
+	if !ok {
+		writeUbiError(w, http.StatusUnauthorized, 1100, "Invalid ticket")
+		return false
+	}
+	if time.Now().After(expiration) {
+		writeUbiError(w, http.StatusUnauthorized, 1101, "Ticket expired")
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("Authorization"), "Basic ") {
+		writeUbiError(w, http.StatusUnauthorized, 1, "Invalid credentials")
+		return
+	}
+	if fixture, ok := s.fixtures[Sessions]; ok {
+		writeJSON(w, fixture)
+		return
+	}
+	writeJSON(w, s.issueTicket(time.Now().Add(3*time.Hour)))
+}
+
+// issueTicket registers a new ticket and returns the corresponding sessions response.
+func (s *Server) issueTicket(expiration time.Time) []byte {
+	token := fmt.Sprintf("ticket-%d", len(s.tickets)+1)
+	s.tickets[token] = expiration
+	data, _ := json.Marshal(map[string]string{
+		"nameOnPlatform": "TestUser",
+		"profileId":      ProfileID,
+		"sessionId":      fmt.Sprintf("session-%d", len(s.tickets)),
+		"expiration":     expiration.UTC().Format(time.RFC3339Nano),
+		"ticket":         token,
+	})
+	return data
+}
+
+func (s *Server) handleProfiles(w http.ResponseWriter, r *http.Request) {
+	if fixture, ok := s.fixtures[Profiles]; ok {
+		writeJSON(w, fixture)
+		return
+	}
+	name := r.URL.Query().Get("namesOnPlatform")
+	data, _ := json.Marshal(map[string]any{
+		"profiles": []map[string]string{
+			{
+				"profileId":      ProfileID,
+				"userId":         ProfileID,
+				"idOnPlatform":   ProfileID,
+				"platformType":   "uplay",
+				"nameOnPlatform": name,
+			},
+		},
+	})
+	writeJSON(w, data)
+}
+
+func (s *Server) handlePlayerStats(w http.ResponseWriter, r *http.Request) {
+	aggregation := r.URL.Query().Get("aggregation")
+	fixture, ok := s.statsFixtures[aggregation]
+	if !ok {
+		writeUbiError(w, http.StatusBadRequest, 400, "unsupported aggregation "+aggregation)
+		return
+	}
+	writeJSON(w, fixture)
+}
+
+func writeJSON(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func writeUbiError(w http.ResponseWriter, statusCode int, errorCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(ubiError(errorCode, message))
+}
+
+func ubiError(errorCode int, message string) []byte {
+	data, _ := json.Marshal(map[string]any{
+		"errorCode":    errorCode,
+		"httpCode":     0,
+		"message":      message,
+		"moreInfo":     "",
+		"errorContext": "Profiles",
+	})
+	return data
+}
@@ -2,6 +2,8 @@ package request
 
 import (
 	"encoding/json"
+	stderrors "errors"
+	"fmt"
 	"io"
 	"net/http"
 
@@ -15,11 +17,30 @@ type ubiErrResp struct {
 	Message   string      `json:"message"`
 }
 
+// StatusError is returned if the API responded with an error and a status code other than 200.
+type StatusError struct {
+	StatusCode int
+	Err        error // error contained in the response
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %v", e.StatusCode, e.Err)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
 // Plain executes r with the default HTTP client and returns the plain body.
 // Remember to close it after reading.
 func Plain(r *http.Request) (io.ReadCloser, error) {
+	return PlainWithClient(nil, r)
+}
+
+// PlainWithClient performs the same as Plain, but uses client (or the default HTTP client if nil).
+func PlainWithClient(client *http.Client, r *http.Request) (io.ReadCloser, error) {
 	r.Header.Add("User-Agent", constants.USER_AGENT)
-	resp, err := http.DefaultClient.Do(r)
+	resp, err := orDefault(client).Do(r)
 	if err != nil {
 		return nil, err
 	}
@@ -28,30 +49,61 @@ func Plain(r *http.Request) (io.ReadCloser, error) {
 
 // JSON executes r with the default HTTP client and performs API-related processing such as deserialization and error-checking.
 // If no errors occur, it attempts to unmarshal the response body into dst.
-func JSON(r *http.Request, dst any) (err error) {
+func JSON(r *http.Request, dst any) error {
+	return JSONWithClient(nil, r, dst)
+}
+
+// JSONWithClient performs the same as JSON, but uses client (or the default HTTP client if nil).
+func JSONWithClient(client *http.Client, r *http.Request, dst any) (err error) {
+	var data []byte
+	data, err = BytesWithClient(client, r)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, dst)
+	return
+}
+
+// Bytes executes r with the default HTTP client and performs API-related error-checking.
+// If no errors occur, it returns the raw JSON response body.
+func Bytes(r *http.Request) ([]byte, error) {
+	return BytesWithClient(nil, r)
+}
+
+// BytesWithClient performs the same as Bytes, but uses client (or the default HTTP client if nil).
+// Errors contained in responses with a status code other than 200 are returned as *StatusError.
+func BytesWithClient(client *http.Client, r *http.Request) (data []byte, err error) {
 	r.Header.Add("User-Agent", constants.USER_AGENT)
 	r.Header.Add("Accept", "application/json")
 	var resp *http.Response
-	resp, err = http.DefaultClient.Do(r)
+	resp, err = orDefault(client).Do(r)
 	if err != nil {
 		return
 	}
+	defer func() {
+		err = stderrors.Join(err, resp.Body.Close())
+	}()
 
-	var data []byte
 	data, err = io.ReadAll(resp.Body)
 	if err != nil {
 		return
 	}
 	if err = checkForErrors(data); err != nil {
 		if resp.StatusCode != 200 {
-			err = errors.Wrapf(err, "unexpected status code %d", resp.StatusCode)
+			err = &StatusError{StatusCode: resp.StatusCode, Err: err}
 		}
-		return
+		data = nil
 	}
-	err = json.Unmarshal(data, dst)
 	return
 }
 
+func orDefault(client *http.Client) *http.Client {
+	if client == nil {
+		return http.DefaultClient
+	}
+	return client
+}
+
 func checkForErrors(data []byte) error {
 	var errData ubiErrResp
 	if err := json.Unmarshal(data, &errData); err != nil {
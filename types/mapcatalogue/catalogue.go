@@ -0,0 +1,123 @@
+package mapcatalogue
+
+// CatalogueVersion is the season (slug) the catalogue reflects.
+// It changes whenever maps are added, reworked or the ranked pool changes.
+const CatalogueVersion = "Y9S1"
+
+// site creates a bombsite from two rooms given as floor and name.
+func site(floorA Floor, roomA string, floorB Floor, roomB string) Bombsite {
+	return Bombsite{Rooms: []Room{{Floor: floorA, Name: roomA}, {Floor: floorB, Name: roomB}}}
+}
+
+var catalogue = []Map{
+	{Name: "Bank", Bombsites: []Bombsite{
+		site(Second, "Executive Lounge", Second, "CEO Office"),
+		site(First, "Open Area", First, "Staff Room"),
+		site(First, "Tellers' Office", First, "Archives"),
+		site(Basement, "Lockers", Basement, "CCTV Room"),
+	}},
+	{Name: "Border", Bombsites: []Bombsite{
+		site(Second, "Armory Lockers", Second, "Archives"),
+		site(First, "Ventilation Room", First, "Workshop"),
+		site(First, "Customs Inspection", First, "Supply Room"),
+		site(First, "Tellers", First, "Bathroom"),
+	}},
+	{Name: "Chalet", Bombsites: []Bombsite{
+		site(Second, "Master Bedroom", Second, "Office"),
+		site(First, "Bar", First, "Gaming Room"),
+		site(First, "Dining Room", First, "Kitchen"),
+		site(Basement, "Wine Cellar", Basement, "Snowmobile Garage"),
+	}},
+	{Name: "Clubhouse", Bombsites: []Bombsite{
+		site(Second, "Gym", Second, "Bedroom"),
+		site(Second, "CCTV Room", Second, "Cash Room"),
+		site(First, "Bar", First, "Stage"),
+		site(Basement, "Church", Basement, "Arsenal Room"),
+	}},
+	{Name: "Coastline", Bombsites: []Bombsite{
+		site(Second, "Theater", Second, "Penthouse"),
+		site(Second, "Hookah Lounge", Second, "Billiards Room"),
+		site(First, "Kitchen", First, "Service Entrance"),
+		site(First, "Blue Bar", First, "Sunrise Bar"),
+	}},
+	{Name: "Consulate", Bombsites: []Bombsite{
+		site(Second, "Consul Office", Second, "Meeting Room"),
+		site(First, "Lobby", First, "Press Room"),
+		site(First, "Archives", First, "Tellers"),
+		site(Basement, "Garage", Basement, "Cafeteria"),
+	}},
+	{Name: "Emerald Plains", Bombsites: []Bombsite{
+		site(Second, "CEO Office", Second, "Administration"),
+		site(Second, "Private Gallery", Second, "Meeting Room"),
+		site(First, "Bar", First, "Lounge"),
+		site(First, "Dining", First, "Kitchen"),
+	}},
+	{Name: "Kafe Dostoyevsky", Aliases: []string{"Kafe"}, Bombsites: []Bombsite{
+		site(Third, "Bar", Third, "Cocktail Lounge"),
+		site(Second, "Fireplace Hall", Second, "Mining Room"),
+		site(First, "Kitchen Service", First, "Kitchen Cooking"),
+	}},
+	{Name: "Kanal", Bombsites: []Bombsite{
+		site(Second, "Server Room", Second, "Radar Room"),
+		site(First, "Security Room", First, "Map Room"),
+		site(First, "Coast Guard Meeting Room", First, "Lounge"),
+		site(Basement, "Supply Room", Basement, "Kayaks"),
+	}},
+	// The bombsites of Lair and Nighthaven Labs have not been compiled yet,
+	// Map.Bombsite and NormalizeBombsites report all of their bombsites as unknown.
+	{Name: "Lair"},
+	{Name: "Nighthaven Labs", Aliases: []string{"Nighthaven"}},
+	{Name: "Oregon", Bombsites: []Bombsite{
+		site(Second, "Kids' Dorms", Second, "Dorms Main Hall"),
+		site(First, "Kitchen", First, "Dining Hall"),
+		site(First, "Meeting Hall", First, "Kitchen"),
+		site(Basement, "Laundry Room", Basement, "Supply Room"),
+	}},
+	{Name: "Outback", Bombsites: []Bombsite{
+		site(Second, "Laundry", Second, "Games Room"),
+		site(Second, "Party Room", Second, "Office"),
+		site(First, "Nature Room", First, "Bushranger Room"),
+		site(First, "Compressor Room", First, "Gear Store"),
+	}},
+	{Name: "Skyscraper", Bombsites: []Bombsite{
+		site(Second, "Tea Room", Second, "Karaoke"),
+		site(Second, "Exhibition", Second, "Office"),
+		site(First, "Kitchen", First, "BBQ"),
+		site(First, "Bedroom", First, "Bathroom"),
+	}},
+	{Name: "Theme Park", Bombsites: []Bombsite{
+		site(Second, "Initiation Room", Second, "Office"),
+		site(Second, "Bunk", Second, "Day Care"),
+		site(First, "Throne Room", First, "Armory"),
+		site(First, "Lab", First, "Storage"),
+	}},
+	{Name: "Villa", Bombsites: []Bombsite{
+		site(Second, "Aviator Room", Second, "Games Room"),
+		site(Second, "Trophy Room", Second, "Statuary Room"),
+		site(First, "Living Room", First, "Library"),
+		site(First, "Dining Room", First, "Kitchen"),
+	}},
+}
+
+// y8Pool is the ranked pool of Y8S1 to Y8S3.
+var y8Pool = []string{
+	"Bank", "Border", "Chalet", "Clubhouse", "Coastline", "Consulate", "Emerald Plains", "Kafe Dostoyevsky",
+	"Kanal", "Nighthaven Labs", "Oregon", "Outback", "Skyscraper", "Theme Park", "Villa",
+}
+
+// rankedPools contains the canonical names of the maps in the ranked pool per season.
+// Seasons before Y8S1 are not covered.
+var rankedPools = map[string][]string{
+	"Y8S1": y8Pool,
+	"Y8S2": y8Pool,
+	"Y8S3": y8Pool,
+	"Y8S4": append(append([]string(nil), y8Pool...), "Lair"),
+	"Y9S1": append(append([]string(nil), y8Pool...), "Lair"),
+}
+
+// roomAliases contains alternative English spellings of rooms (keyed by their normalized name) used in stats responses of some seasons.
+// Localized room names are not covered, bombsites named in another language are reported as unknown.
+var roomAliases = map[string][]string{
+	"HOOKAHLOUNGE": {"Hookah"},
+	"KIDSDORMS":    {"Kids Dorm"},
+}
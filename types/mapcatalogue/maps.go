@@ -0,0 +1,348 @@
+// Package mapcatalogue contains a static catalogue of maps and their bombsites,
+// allowing to normalize the map and bombsite names contained in stats responses.
+// It is not named maps to avoid clashing with the maps package of the standard library.
+package mapcatalogue
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/stnokott/r6api/internal/keys"
+	"github.com/stnokott/r6api/types/stats"
+)
+
+type Floor string
+
+const (
+	Basement Floor = "B"
+	First    Floor = "1F"
+	Second   Floor = "2F"
+	Third    Floor = "3F"
+)
+
+// Room is a single room of a bombsite.
+type Room struct {
+	Floor Floor
+	Name  string
+}
+
+func (r Room) String() string {
+	return string(r.Floor) + " " + r.Name
+}
+
+// hasName returns true if key is the normalized name of r or one of its aliases.
+func (r Room) hasName(key string) bool {
+	name := keys.Normalize(r.Name)
+	if name == key {
+		return true
+	}
+	for _, alias := range roomAliases[name] {
+		if keys.Normalize(alias) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Bombsite is a pair of rooms which can be defended in the bomb game mode.
+type Bombsite struct {
+	Rooms []Room
+}
+
+// ID returns an identifier of the bombsite which is stable across seasons, e.g. "2F-GYM_2F-BEDROOM".
+func (b Bombsite) ID() string {
+	parts := make([]string, len(b.Rooms))
+	for i, r := range b.Rooms {
+		parts[i] = keys.Normalize(string(r.Floor)) + "-" + keys.Normalize(r.Name)
+	}
+	return strings.Join(parts, "_")
+}
+
+// String returns the name of the bombsite in the format used by stats responses, e.g. "2F Gym / 2F Bedroom".
+func (b Bombsite) String() string {
+	parts := make([]string, len(b.Rooms))
+	for i, r := range b.Rooms {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, " / ")
+}
+
+// matches returns true if all rooms named in name belong to b, regardless of their order and whether floors are included.
+func (b Bombsite) matches(name string) bool {
+	rooms := splitRooms(name)
+	if len(rooms) != len(b.Rooms) {
+		return false
+	}
+	for _, room := range rooms {
+		found := false
+		for _, r := range b.Rooms {
+			if r.hasName(room.name) && (room.floor == "" || keys.Normalize(string(r.Floor)) == room.floor) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+type parsedRoom struct {
+	floor string
+	name  string
+}
+
+// splitRooms splits a bombsite name such as "2F Gym / 2F Bedroom" or "Bedroom, Gym" into its normalized rooms.
+func splitRooms(name string) []parsedRoom {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '/' || r == ',' || r == '|'
+	})
+	rooms := make([]parsedRoom, 0, len(parts))
+	for _, part := range parts {
+		words := strings.Fields(part)
+		if len(words) == 0 {
+			continue
+		}
+		var room parsedRoom
+		if isFloor(words[0]) {
+			room.floor = keys.Normalize(words[0])
+			words = words[1:]
+		}
+		room.name = keys.Normalize(strings.Join(words, ""))
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+func isFloor(s string) bool {
+	switch keys.Normalize(s) {
+	case keys.Normalize(string(Basement)), keys.Normalize(string(First)), keys.Normalize(string(Second)), keys.Normalize(string(Third)):
+		return true
+	}
+	return false
+}
+
+// Map is a single map of the catalogue.
+type Map struct {
+	Name      string     // canonical name, e.g. "Kafe Dostoyevsky"
+	Aliases   []string   // alternative names, e.g. "Kafe"
+	Bombsites []Bombsite // empty for maps whose bombsites are not part of the catalogue yet, currently Lair and Nighthaven Labs
+}
+
+// Bombsite returns the bombsite of m matching name, which may be a bombsite name as contained in stats responses.
+// Rooms may be in any order, floors can be omitted and case, whitespace, punctuation and diacritics are ignored.
+// Returns false if no bombsite matches, e.g. because the map was reworked, the name is localized
+// or the bombsites of m are not part of the catalogue (see Map.Bombsites).
+func (m Map) Bombsite(name string) (Bombsite, bool) {
+	for _, b := range m.Bombsites {
+		if b.matches(name) {
+			return copyBombsite(b), true
+		}
+	}
+	return Bombsite{}, false
+}
+
+// InRankedPool returns true if m was part of the ranked pool in season (e.g. "Y8S2").
+// known is false if the ranked pool of season is not part of the catalogue.
+func (m Map) InRankedPool(season string) (inPool bool, known bool) {
+	pool, known := rankedPools[strings.ToUpper(season)]
+	for _, name := range pool {
+		if name == m.Name {
+			return true, known
+		}
+	}
+	return false, known
+}
+
+var byKey = map[string]int{}
+
+func init() {
+	for i, m := range catalogue {
+		byKey[keys.Normalize(m.Name)] = i
+		for _, alias := range m.Aliases {
+			byKey[keys.Normalize(alias)] = i
+		}
+	}
+}
+
+// Lookup returns the map for name, which may be a name as contained in stats responses (e.g. "KAFE DOSTOYEVSKY").
+// Returns false if the map is not part of the catalogue.
+func Lookup(name string) (Map, bool) {
+	i, ok := byKey[keys.Normalize(name)]
+	if !ok {
+		return Map{}, false
+	}
+	return copyMap(catalogue[i]), true
+}
+
+// All returns all maps of the catalogue ordered by name.
+func All() []Map {
+	result := make([]Map, len(catalogue))
+	for i, m := range catalogue {
+		result[i] = copyMap(m)
+	}
+	return result
+}
+
+// RankedPool returns the maps of the ranked pool in season (e.g. "Y8S2") ordered by name.
+// Returns false if the ranked pool of season is not part of the catalogue.
+func RankedPool(season string) ([]Map, bool) {
+	pool, ok := rankedPools[strings.ToUpper(season)]
+	if !ok {
+		return nil, false
+	}
+	result := make([]Map, 0, len(pool))
+	for _, name := range pool {
+		m, _ := Lookup(name)
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, true
+}
+
+func copyMap(m Map) Map {
+	m.Aliases = append([]string(nil), m.Aliases...)
+	bombsites := make([]Bombsite, len(m.Bombsites))
+	for i, b := range m.Bombsites {
+		bombsites[i] = copyBombsite(b)
+	}
+	m.Bombsites = bombsites
+	return m
+}
+
+func copyBombsite(b Bombsite) Bombsite {
+	b.Rooms = append([]Room(nil), b.Rooms...)
+	return b
+}
+
+// NormalizeMapStats returns the map stats in s keyed by the canonical map names.
+// Stats of multiple keys belonging to the same map are combined with stats.Aggregate, including their bombsites.
+// Keys not matching any map are returned in unknown, ordered alphabetically, and omitted from normalized.
+func NormalizeMapStats(s map[string]stats.NamedMapStatDetails) (normalized map[string]stats.NamedMapStatDetails, unknown []string) {
+	perMap := map[string][]stats.NamedMapStatDetails{}
+	for _, key := range keys.Sorted(s) {
+		m, ok := Lookup(key)
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		perMap[m.Name] = append(perMap[m.Name], s[key])
+	}
+
+	normalized = make(map[string]stats.NamedMapStatDetails, len(perMap))
+	for name, entries := range perMap {
+		if len(entries) == 1 {
+			normalized[name] = entries[0]
+			continue
+		}
+		m, _ := Lookup(name)
+		normalized[name] = combineMapStats(m, entries)
+	}
+	return
+}
+
+// combineMapStats combines the stats of entries, which all belong to m, with stats.Aggregate.
+func combineMapStats(m Map, entries []stats.NamedMapStatDetails) stats.NamedMapStatDetails {
+	var (
+		result          stats.NamedMapStatDetails
+		all             []stats.DetailedStats
+		attack, defence []stats.DetailedStats
+		bombsites       *stats.BombsiteGamemodeStats
+	)
+	for _, e := range entries {
+		all = append(all, e.DetailedStats)
+		if e.Attack != nil {
+			attack = append(attack, *e.Attack)
+		}
+		if e.Defence != nil {
+			defence = append(defence, *e.Defence)
+		}
+		if e.Bombsites != nil {
+			if bombsites == nil {
+				bombsites = new(stats.BombsiteGamemodeStats)
+			}
+			bombsites.All = append(bombsites.All, e.Bombsites.All...)
+			bombsites.Attack = append(bombsites.Attack, e.Bombsites.Attack...)
+			bombsites.Defence = append(bombsites.Defence, e.Bombsites.Defence...)
+		}
+	}
+	result.DetailedStats = stats.Aggregate(all...)
+	if len(attack) > 0 {
+		a := stats.Aggregate(attack...)
+		result.Attack = &a
+	}
+	if len(defence) > 0 {
+		d := stats.Aggregate(defence...)
+		result.Defence = &d
+	}
+	if bombsites != nil {
+		bombsites.All = combineBombsites(m, bombsites.All)
+		bombsites.Attack = combineBombsites(m, bombsites.Attack)
+		bombsites.Defence = combineBombsites(m, bombsites.Defence)
+	}
+	result.Bombsites = bombsites
+	return result
+}
+
+// combineBombsites combines the entries of s belonging to the same bombsite of m with stats.Aggregate,
+// keeping the name and position of the first entry per bombsite.
+// Bombsites are identified by Bombsite.ID, entries not matching any bombsite of m by their normalized name.
+func combineBombsites(m Map, s []stats.BombsiteTeamRoleStats) []stats.BombsiteTeamRoleStats {
+	var (
+		result  []stats.BombsiteTeamRoleStats
+		perSite [][]stats.DetailedStats
+		indices = map[string]int{}
+	)
+	for _, entry := range s {
+		key := keys.Normalize(entry.Name)
+		if b, ok := m.Bombsite(entry.Name); ok {
+			key = b.ID()
+		}
+		i, ok := indices[key]
+		if !ok {
+			i = len(result)
+			indices[key] = i
+			result = append(result, stats.BombsiteTeamRoleStats{Name: entry.Name})
+			perSite = append(perSite, nil)
+		}
+		perSite[i] = append(perSite[i], entry.DetailedStats)
+	}
+	for i, entries := range perSite {
+		if len(entries) == 1 {
+			result[i].DetailedStats = entries[0]
+			continue
+		}
+		result[i].DetailedStats = stats.Aggregate(entries...)
+	}
+	return result
+}
+
+// NormalizeBombsites returns the bombsite stats in s, which need to belong to map m, keyed by Bombsite.ID.
+// This allows comparing bombsites across seasons even if their names changed.
+// Stats of multiple entries belonging to the same bombsite are combined with stats.Aggregate.
+// Names not matching any bombsite of m are returned in unknown, ordered alphabetically, and omitted from normalized.
+func NormalizeBombsites(m Map, s []stats.BombsiteTeamRoleStats) (normalized map[string]stats.DetailedStats, unknown []string) {
+	perBombsite := map[string][]stats.DetailedStats{}
+	for _, entry := range s {
+		b, ok := m.Bombsite(entry.Name)
+		if !ok {
+			unknown = append(unknown, entry.Name)
+			continue
+		}
+		perBombsite[b.ID()] = append(perBombsite[b.ID()], entry.DetailedStats)
+	}
+	sort.Strings(unknown)
+
+	normalized = make(map[string]stats.DetailedStats, len(perBombsite))
+	for id, entries := range perBombsite {
+		if len(entries) == 1 {
+			normalized[id] = entries[0]
+			continue
+		}
+		normalized[id] = stats.Aggregate(entries...)
+	}
+	return
+}
@@ -0,0 +1,135 @@
+package mapcatalogue
+
+import (
+	"testing"
+
+	"github.com/stnokott/r6api/internal/cataloguetest"
+	"github.com/stnokott/r6api/types/stats"
+)
+
+func TestCatalogue(t *testing.T) {
+	for _, m := range All() {
+		// see catalogue for bombsites not compiled yet
+		if withoutBombsites := m.Name == "Lair" || m.Name == "Nighthaven Labs"; withoutBombsites != (len(m.Bombsites) == 0) {
+			t.Errorf("%s: unexpected bombsites %v", m.Name, m.Bombsites)
+		}
+		ids := make([]string, 0, len(m.Bombsites))
+		for _, b := range m.Bombsites {
+			ids = append(ids, b.ID())
+			if found, ok := m.Bombsite(b.String()); !ok || found.ID() != b.ID() {
+				t.Errorf("%s: bombsite %s does not match its own name", m.Name, b)
+			}
+		}
+		cataloguetest.CheckUnique(t, m.Name+" bombsite", ids)
+	}
+	for season, pool := range rankedPools {
+		for _, name := range pool {
+			if _, ok := Lookup(name); !ok {
+				t.Errorf("map %s of ranked pool %s missing from catalogue", name, season)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	cataloguetest.RunLookups(t, func(name string) (string, bool) {
+		m, ok := Lookup(name)
+		return m.Name, ok
+	},
+		cataloguetest.LookupCase{Name: "KAFE DOSTOYEVSKY", Want: "Kafe Dostoyevsky"},
+		cataloguetest.LookupCase{Name: "kafe", Want: "Kafe Dostoyevsky"},
+		cataloguetest.LookupCase{Name: "Kafe-Dostoyevsky", Want: "Kafe Dostoyevsky"},
+		cataloguetest.LookupCase{Name: "House"},
+	)
+}
+
+func TestBombsite(t *testing.T) {
+	clubhouse, _ := Lookup("CLUBHOUSE")
+	tests := []struct {
+		name   string
+		wantID string
+	}{
+		{name: "1F Bar / 1F Stage", wantID: "1F-BAR_1F-STAGE"},
+		{name: "1F Stage, 1F Bar", wantID: "1F-BAR_1F-STAGE"},
+		{name: "bar/stage", wantID: "1F-BAR_1F-STAGE"},
+		{name: "2F Bar / 2F Stage"},
+		{name: "1F Bar"},
+		{name: "2F Gym / 2F Bedroom", wantID: "2F-GYM_2F-BEDROOM"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, ok := clubhouse.Bombsite(tt.name)
+			if ok != (tt.wantID != "") || b.ID() != tt.wantID {
+				t.Errorf("want '%s', got '%s' (%t)", tt.wantID, b.ID(), ok)
+			}
+		})
+	}
+
+	oregon, _ := Lookup("OREGON")
+	if b, ok := oregon.Bombsite("2F Kids Dorm / 2F Dorms Main Hall"); !ok || b.Rooms[0].Name != "Kids' Dorms" {
+		t.Errorf("alias should match, got %+v", b)
+	}
+	if b, _ := oregon.Bombsite("1F Kitchen / 1F Meeting Hall"); b.Rooms[0].Name != "Meeting Hall" {
+		t.Errorf("want meeting hall bombsite, got %s", b)
+	}
+}
+
+func TestRankedPool(t *testing.T) {
+	pool, ok := RankedPool("y8s2")
+	if !ok || len(pool) != 15 || pool[0].Name != "Bank" {
+		t.Errorf("unexpected pool %+v", pool)
+	}
+	if _, ok = RankedPool("Y1S1"); ok {
+		t.Error("pools before Y8S1 are not covered")
+	}
+
+	lair, _ := Lookup("Lair")
+	if inPool, known := lair.InRankedPool("Y8S2"); inPool || !known {
+		t.Errorf("want Lair not in Y8S2 pool, got %t (%t)", inPool, known)
+	}
+	if inPool, _ := lair.InRankedPool("Y9S1"); !inPool {
+		t.Error("want Lair in Y9S1 pool")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	detailed := cataloguetest.Detailed
+
+	mapStats, unknown := NormalizeMapStats(map[string]stats.NamedMapStatDetails{
+		"KAFE DOSTOYEVSKY": {DetailedStats: detailed(10, 12)},
+		"KAFE":             {DetailedStats: detailed(5, 6), Attack: &stats.DetailedStats{}},
+		"CLUBHOUSE": {DetailedStats: detailed(3, 4), Bombsites: &stats.BombsiteGamemodeStats{All: []stats.BombsiteTeamRoleStats{
+			{Name: "1F Bar / 1F Stage", DetailedStats: detailed(2, 2)},
+			{Name: "B Tunnel / B Vault", DetailedStats: detailed(1, 1)},
+		}}},
+		"Clubhouse": {DetailedStats: detailed(2, 3), Bombsites: &stats.BombsiteGamemodeStats{All: []stats.BombsiteTeamRoleStats{
+			{Name: "1F Stage / 1F Bar", DetailedStats: detailed(1, 1)},
+			{Name: "B Tunnel / B Vault", DetailedStats: detailed(1, 2)},
+		}}},
+		"HOUSE": {DetailedStats: detailed(1, 1)},
+	})
+	if len(mapStats) != 2 || mapStats["Kafe Dostoyevsky"].Kills != 15 || mapStats["Kafe Dostoyevsky"].Attack == nil || mapStats["Clubhouse"].Kills != 5 {
+		t.Errorf("unexpected map stats %+v", mapStats)
+	}
+	if got := mapStats["Clubhouse"].Bombsites.All; len(got) != 2 ||
+		got[0].Name != "1F Bar / 1F Stage" || got[0].Kills != 3 || got[0].RoundsPlayed != 3 ||
+		got[1].Name != "B Tunnel / B Vault" || got[1].Kills != 2 || got[1].RoundsPlayed != 3 {
+		t.Errorf("want bombsites combined by ID, got %+v", got)
+	}
+	if len(unknown) != 1 || unknown[0] != "HOUSE" {
+		t.Errorf("want unknown [HOUSE], got %v", unknown)
+	}
+
+	clubhouse, _ := Lookup("Clubhouse")
+	bombsites, unknown := NormalizeBombsites(clubhouse, []stats.BombsiteTeamRoleStats{
+		{Name: "1F Bar / 1F Stage", DetailedStats: detailed(4, 4)},
+		{Name: "1F Stage / 1F Bar", DetailedStats: detailed(2, 4)},
+		{Name: "1F Garage / 1F Kitchen", DetailedStats: detailed(1, 1)},
+	})
+	if len(bombsites) != 1 || bombsites["1F-BAR_1F-STAGE"].Kills != 6 || bombsites["1F-BAR_1F-STAGE"].RoundsPlayed != 8 {
+		t.Errorf("unexpected bombsites %+v", bombsites)
+	}
+	if len(unknown) != 1 {
+		t.Errorf("want one unknown bombsite, got %v", unknown)
+	}
+}
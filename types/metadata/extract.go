@@ -0,0 +1,130 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ExtractStep is a step of extracting metadata from the glossary page.
+type ExtractStep string
+
+const (
+	StepLocateScript  ExtractStep = "locate script"  // finding the script containing the preloaded state
+	StepDecodeState   ExtractStep = "decode state"   // decoding the preloaded state object
+	StepLocateSeasons ExtractStep = "locate seasons" // finding the season list within the state
+	StepDecodeSeasons ExtractStep = "decode seasons" // decoding the seasons of the season list
+)
+
+// maxSnippetLength is the maximum length of ExtractError.Snippet.
+const maxSnippetLength = 2000
+
+// ExtractError is returned if metadata cannot be extracted, e.g. because the structure of the glossary page changed.
+// It contains the failed step and the offending part of the page, which should be included in bug reports (see WriteReport).
+type ExtractError struct {
+	Step    ExtractStep
+	Snippet string // offending part of the page, truncated to 2000 characters
+	Err     error
+}
+
+func newExtractError(step ExtractStep, snippet string, err error) *ExtractError {
+	if len(snippet) > maxSnippetLength {
+		snippet = strings.ToValidUTF8(snippet[:maxSnippetLength], "") + "…"
+	}
+	return &ExtractError{Step: step, Snippet: snippet, Err: err}
+}
+
+func (e *ExtractError) Error() string {
+	return fmt.Sprintf("could not extract metadata (step '%s'): %v", e.Step, e.Err)
+}
+
+func (e *ExtractError) Unwrap() error {
+	return e.Err
+}
+
+// WriteReport writes a report of the error including the snippet to w, e.g. to attach it to a bug report.
+func (e *ExtractError) WriteReport(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "step: %s\nerror: %v\nsnippet:\n%s\n", e.Step, e.Err, e.Snippet)
+	return err
+}
+
+// FindStateScript returns the script containing the preloaded state among scripts, e.g. all scripts of the glossary page.
+func FindStateScript(scripts []string) (string, error) {
+	for _, script := range scripts {
+		if strings.Contains(script, stateVariable) {
+			return script, nil
+		}
+	}
+	beginnings := make([]string, len(scripts))
+	for i, script := range scripts {
+		if len(script) > 100 {
+			script = script[:100]
+		}
+		beginnings[i] = fmt.Sprintf("script %d: %s", i, script)
+	}
+	return "", newExtractError(
+		StepLocateScript,
+		strings.Join(beginnings, "\n"),
+		fmt.Errorf("none of %d scripts contains %s", len(scripts), stateVariable),
+	)
+}
+
+// locateSeasons searches state for the season list, which is identified by its structure rather than its location:
+// an array with the key "seasons" whose elements all contain "slug" and "startDate".
+// If multiple such arrays exist, the longest one is returned.
+func locateSeasons(state []byte) (json.RawMessage, error) {
+	var root any
+	if err := json.Unmarshal(state, &root); err != nil {
+		return nil, newExtractError(StepLocateSeasons, string(state), err)
+	}
+
+	var best []any
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if seasons, ok := v[k].([]any); ok && k == "seasons" && isSeasonList(seasons) && len(seasons) > len(best) {
+					best = seasons
+				}
+				walk(v[k])
+			}
+		case []any:
+			for _, elem := range v {
+				walk(elem)
+			}
+		}
+	}
+	walk(root)
+
+	if best == nil {
+		return nil, newExtractError(StepLocateSeasons, string(state), fmt.Errorf("no season list found in state"))
+	}
+	return json.Marshal(best)
+}
+
+func isSeasonList(list []any) bool {
+	if len(list) == 0 {
+		return false
+	}
+	for _, elem := range list {
+		obj, ok := elem.(map[string]any)
+		if !ok {
+			return false
+		}
+		if _, ok = obj["slug"].(string); !ok {
+			return false
+		}
+		if _, ok = obj["startDate"].(string); !ok {
+			return false
+		}
+	}
+	return true
+}
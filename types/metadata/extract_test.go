@@ -0,0 +1,93 @@
+package metadata
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFindStateScript(t *testing.T) {
+	scripts := []string{"window.dataLayer = [];", `window.__PRELOADED_STATE__ = {}`, "console.log(1)"}
+	script, err := FindStateScript(scripts)
+	if err != nil || script != scripts[1] {
+		t.Errorf("want second script, got '%s' (%v)", script, err)
+	}
+
+	_, err = FindStateScript([]string{"window.dataLayer = [];"})
+	var extractErr *ExtractError
+	if !errors.As(err, &extractErr) || extractErr.Step != StepLocateScript || !strings.Contains(extractErr.Snippet, "dataLayer") {
+		t.Errorf("unexpected error %#v", err)
+	}
+}
+
+func TestNewStructural(t *testing.T) {
+	const season = `{"slug": "Y8S2", "localizedItems": {"title": "Dread Factor"}, "startDate": "2023-06-13T00:00:00.000Z", "rankList": {"data": {"ranks": [{"min": 1000, "max": 1099, "slug": "copper-5"}]}}}`
+
+	tests := []struct {
+		name     string
+		script   string
+		wantStep ExtractStep
+	}{
+		{
+			name:   "renamed key",
+			script: `window.__PRELOADED_STATE__ = {"ContentfulGraphQl": {"Other": {"seasons": []}, "Seasons Card-1": {"content": {"seasons": [` + season + `]}}}}`,
+		},
+		{
+			name:   "moved list",
+			script: `window.__PRELOADED_STATE__ = {"data": [{"seasons": [` + season + `]}]}`,
+		},
+		{
+			name:     "invalid state",
+			script:   `window.__PRELOADED_STATE__ = {"seasons": [`,
+			wantStep: StepDecodeState,
+		},
+		{
+			name:     "missing list",
+			script:   `window.__PRELOADED_STATE__ = {"seasons": [{"slug": "Y8S2"}]}`,
+			wantStep: StepLocateSeasons,
+		},
+		{
+			name:     "invalid season",
+			script:   `window.__PRELOADED_STATE__ = {"seasons": [{"slug": "Y8S2", "startDate": "yesterday"}]}`,
+			wantStep: StepDecodeSeasons,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.script)
+			if tt.wantStep == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(m.Seasons) != 1 || m.Seasons[0].Name != "Dread Factor" || len(m.Seasons[0].Ranks) != 1 {
+					t.Errorf("unexpected seasons %+v", m.Seasons)
+				}
+				return
+			}
+			var extractErr *ExtractError
+			if !errors.As(err, &extractErr) {
+				t.Fatalf("want *ExtractError, got %v", err)
+			}
+			if extractErr.Step != tt.wantStep || extractErr.Snippet == "" {
+				t.Errorf("want step '%s' with snippet, got '%s' with snippet '%s'", tt.wantStep, extractErr.Step, extractErr.Snippet)
+			}
+		})
+	}
+}
+
+func TestExtractErrorReport(t *testing.T) {
+	err := newExtractError(StepLocateSeasons, strings.Repeat("ä", maxSnippetLength), errors.New("not found"))
+	if !strings.HasSuffix(err.Snippet, "…") || len(err.Snippet) > maxSnippetLength+len("…") {
+		t.Errorf("snippet should be truncated, got length %d", len(err.Snippet))
+	}
+
+	var buf bytes.Buffer
+	if err := err.WriteReport(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if report := buf.String(); !strings.Contains(report, "locate seasons") || !strings.Contains(report, "not found") || !strings.Contains(report, "ä") {
+		t.Errorf("incomplete report: %s", report)
+	}
+}
@@ -6,27 +6,36 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/robertkrimen/otto"
 )
 
 const URL string = "https://www.ubisoft.com/de-de/game/rainbow-six/siege/stats/glossary/fa94e165-6328-4a9b-8581-81735ffaba27"
 
+// stateVariable is the Javascript variable the metadata is assigned to.
+const stateVariable = "window.__PRELOADED_STATE__"
+
+// errStateNotJSON is returned by extractStateJSON if the state variable is assigned, but its value is not valid JSON.
+var errStateNotJSON = fmt.Errorf("%s is not valid JSON", stateVariable)
+
 // New creates a new instance, parsing scriptJS.
-// The parameter should be a Javascript string starting with "window.__PRELOADED_STATE__ = <JS object>".
+// The parameter should be a Javascript string containing "window.__PRELOADED_STATE__ = <JS object>", see FindStateScript.
+// The object is decoded directly if it is valid JSON, otherwise it is evaluated in a Javascript VM, which is considerably slower.
+// Only a value which is not valid JSON falls back to the VM, a missing or unassigned state variable fails right away.
+// The VM (github.com/robertkrimen/otto) is therefore still a dependency of this module.
+// Returns an *ExtractError if the metadata cannot be extracted.
 // This method should only be called internally.
 func New(scriptJS string) (*Metadata, error) {
-	vm := otto.New()
-	if _, err := vm.Run(strings.Replace(scriptJS, "window.__PRELOADED_STATE__", "state", 1)); err != nil {
-		return nil, fmt.Errorf("could not run JS in VM: %w", err)
-	}
-	state, err := vm.Get("state")
-	if err != nil {
-		return nil, fmt.Errorf("could not get VM variable value: %w", err)
+	stateJSON, err := extractStateJSON(scriptJS)
+	if err != nil && !errors.Is(err, errStateNotJSON) {
+		return nil, newExtractError(StepDecodeState, stateSnippet(scriptJS), err)
 	}
-	stateJSON, err := state.MarshalJSON()
 	if err != nil {
-		return nil, fmt.Errorf("could not marshal JS data to JSON: %w", err)
+		var vmErr error
+		if stateJSON, vmErr = evaluateStateJS(scriptJS); vmErr != nil {
+			return nil, newExtractError(StepDecodeState, stateSnippet(scriptJS), errors.Join(err, vmErr))
+		}
 	}
 
 	m := new(Metadata)
@@ -37,15 +46,72 @@ func New(scriptJS string) (*Metadata, error) {
 	return m, nil
 }
 
+// extractStateJSON returns the object assigned to the state variable in scriptJS, which needs to be valid JSON.
+// Anything following the object (e.g. further statements) is ignored.
+// Returns an error wrapping errStateNotJSON if the object is not valid JSON.
+func extractStateJSON(scriptJS string) ([]byte, error) {
+	i := strings.Index(scriptJS, stateVariable)
+	if i < 0 {
+		return nil, fmt.Errorf("could not find %s in script", stateVariable)
+	}
+	assignment := strings.TrimLeftFunc(scriptJS[i+len(stateVariable):], unicode.IsSpace)
+	if !strings.HasPrefix(assignment, "=") {
+		return nil, fmt.Errorf("%s is not assigned in script", stateVariable)
+	}
+
+	var state json.RawMessage
+	if err := json.NewDecoder(strings.NewReader(assignment[1:])).Decode(&state); err != nil {
+		return nil, fmt.Errorf("%w: %w", errStateNotJSON, err)
+	}
+	return state, nil
+}
+
+// stateSnippet returns the part of scriptJS starting at the state variable, or all of it if the variable is missing.
+func stateSnippet(scriptJS string) string {
+	if i := strings.Index(scriptJS, stateVariable); i >= 0 {
+		return scriptJS[i:]
+	}
+	return scriptJS
+}
+
+// evaluateStateJS evaluates scriptJS in a Javascript VM, returning the value of the state variable as JSON.
+// This supports Javascript literals which are not valid JSON.
+func evaluateStateJS(scriptJS string) ([]byte, error) {
+	vm := otto.New()
+	if _, err := vm.Run("var window = {};"); err != nil {
+		return nil, fmt.Errorf("could not prepare VM: %w", err)
+	}
+	if _, err := vm.Run(scriptJS); err != nil {
+		return nil, fmt.Errorf("could not run JS in VM: %w", err)
+	}
+	state, err := vm.Run(stateVariable)
+	if err != nil {
+		return nil, fmt.Errorf("could not get VM variable value: %w", err)
+	}
+	stateJSON, err := state.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal JS data to JSON: %w", err)
+	}
+	return stateJSON, nil
+}
+
+// Metadata contains information about all seasons.
+// Seasons are indexed by ID when decoded, see New, LoadFrom, Fallback and Copy,
+// so modifying Seasons afterwards does not affect lookups such as SeasonByID.
 type Metadata struct {
 	Seasons []Season
+	// FetchedAt is the time the seasons were retrieved from the glossary page, zero if unknown.
+	// For metadata loaded from a snapshot, it is the time the snapshot was taken.
+	FetchedAt time.Time
+
+	index *seasonIndex // nil if not decoded, see seasonIndex
 }
 
 type Season struct {
-	Name      string
-	Slug      string
-	StartDate time.Time
-	Ranks     []Rank
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	StartDate time.Time `json:"startDate"`
+	Ranks     []Rank    `json:"ranks"` // empty if the ranks of the season are unknown, see Fallback
 }
 
 type Rank struct {
@@ -54,70 +120,69 @@ type Rank struct {
 	Slug   string `json:"slug"`
 }
 
-type rootJSON struct {
-	ContentfulGraphQl map[string]json.RawMessage `json:"ContentfulGraphQl"`
-}
-
-type seasonsJSON struct {
-	Content struct {
-		Seasons []struct {
-			Slug           string `json:"slug"`
-			LocalizedItems struct {
-				Title string `json:"title"`
-			} `json:"localizedItems"`
-			StartDate time.Time `json:"startDate"`
-			RankList  struct {
-				Data struct {
-					Ranks []Rank `json:"ranks"`
-				} `json:"data"`
-			} `json:"rankList"`
-		} `json:"seasons"`
-	} `json:"content"`
+type seasonJSON struct {
+	Slug           string `json:"slug"`
+	LocalizedItems struct {
+		Title string `json:"title"`
+	} `json:"localizedItems"`
+	StartDate time.Time `json:"startDate"`
+	RankList  struct {
+		Data struct {
+			Ranks []Rank `json:"ranks"`
+		} `json:"data"`
+	} `json:"rankList"`
 }
 
+// UnmarshalJSON decodes the preloaded state of the glossary page, locating the season list by its structure.
+// Returns an *ExtractError if the season list cannot be found or decoded.
 func (m *Metadata) UnmarshalJSON(data []byte) error {
-	var root rootJSON
-
-	if err := json.Unmarshal(data, &root); err != nil {
+	seasonList, err := locateSeasons(data)
+	if err != nil {
 		return err
 	}
 
-	for k, v := range root.ContentfulGraphQl {
-		if strings.HasPrefix(k, "G2W Card-") {
-			var seasonsJSON seasonsJSON
-			if err := json.Unmarshal(v, &seasonsJSON); err != nil {
-				return err
-			}
-			seasons := make([]Season, len(seasonsJSON.Content.Seasons))
-			for i, seasonJSON := range seasonsJSON.Content.Seasons {
-				seasons[i] = Season{
-					Name:      seasonJSON.LocalizedItems.Title,
-					Slug:      seasonJSON.Slug,
-					StartDate: seasonJSON.StartDate,
-					Ranks:     seasonJSON.RankList.Data.Ranks,
-				}
+	var seasonsJSON []seasonJSON
+	if err = json.Unmarshal(seasonList, &seasonsJSON); err != nil {
+		return newExtractError(StepDecodeSeasons, string(seasonList), err)
+	}
+	seasons := make([]Season, len(seasonsJSON))
+	for i, seasonJSON := range seasonsJSON {
+		seasons[i] = Season{
+			Name:      seasonJSON.LocalizedItems.Title,
+			Slug:      seasonJSON.Slug,
+			StartDate: seasonJSON.StartDate,
+			Ranks:     seasonJSON.RankList.Data.Ranks,
+		}
+	}
+	m.Seasons = seasons
+	m.index = newSeasonIndex(seasons)
+	return nil
+}
+
+// Copy returns a deep copy of m, which can be modified without affecting m.
+func (m *Metadata) Copy() *Metadata {
+	c := *m
+	if m.Seasons != nil {
+		c.Seasons = make([]Season, len(m.Seasons))
+		for i, season := range m.Seasons {
+			if season.Ranks != nil {
+				season.Ranks = append(make([]Rank, 0, len(season.Ranks)), season.Ranks...)
 			}
-			m.Seasons = seasons
-			return nil
+			c.Seasons[i] = season
 		}
 	}
-	return errors.New("could not find required key in data")
+	c.index = newSeasonIndex(c.Seasons)
+	return &c
 }
 
-// SeasonSlugFromID will return the slug of the season (e.g. "Y7S3") with the provided ID or "" if unknown.
+// SeasonSlugFromID will return the slug of the season (e.g. "Y7S3") with the provided ID or "" if unknown, see SeasonByID.
 func (m *Metadata) SeasonSlugFromID(seasonID int) string {
-	if len(m.Seasons) < seasonID {
-		return ""
-	} else {
-		return m.Seasons[seasonID].Slug
-	}
+	season, _ := m.SeasonByID(seasonID)
+	return season.Slug
 }
 
-// SeasonNameFromID will return the name of the season (e.g. "Brutal Swarm") with the provided ID or "" if unknown.
+// SeasonNameFromID will return the name of the season (e.g. "Brutal Swarm") with the provided ID or "" if unknown, see SeasonByID.
 func (m *Metadata) SeasonNameFromID(seasonID int) string {
-	if len(m.Seasons) < seasonID {
-		return ""
-	} else {
-		return m.Seasons[seasonID].Name
-	}
+	season, _ := m.SeasonByID(seasonID)
+	return season.Name
 }
@@ -0,0 +1,123 @@
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// loadScript returns the content of the script tag of the glossary fixture.
+func loadScript(tb testing.TB) string {
+	tb.Helper()
+	data, err := os.ReadFile("../../r6apitest/testdata/glossary.html")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	html := string(data)
+	start := strings.Index(html, "<script>window.__PRELOADED_STATE__") + len("<script>")
+	end := start + strings.Index(html[start:], "</script>")
+	return html[start:end]
+}
+
+func TestNew(t *testing.T) {
+	script := loadScript(t)
+
+	m, err := New(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slug := m.SeasonSlugFromID(30); slug != "Y8S2" {
+		t.Errorf("want slug Y8S2, got '%s'", slug)
+	}
+
+	// the direct extraction needs to yield the same result as the Javascript VM
+	stateJSON, err := evaluateStateJS(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromVM := new(Metadata)
+	if err = json.Unmarshal(stateJSON, fromVM); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, fromVM) {
+		t.Error("metadata decoded directly differs from metadata evaluated in VM")
+	}
+}
+
+func TestNewFallback(t *testing.T) {
+	// unquoted keys, single quotes and trailing statements are valid Javascript, but not valid JSON
+	script := `window.__PRELOADED_STATE__ = {ContentfulGraphQl: {'G2W Card-1': {content: {seasons: [{slug: 'Y1S0', localizedItems: {title: 'Launch'}, startDate: '2015-12-01T00:00:00.000Z', rankList: {data: {ranks: []}}}]}}}}; window.foo = 1;`
+	if _, err := extractStateJSON(script); err == nil {
+		t.Fatal("expected direct extraction to fail")
+	}
+	m, err := New(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Seasons) != 1 || m.Seasons[0].Name != "Launch" {
+		t.Errorf("unexpected seasons %+v", m.Seasons)
+	}
+
+	// a missing state variable must not be evaluated in the VM
+	_, err = New(`window.__OTHER_STATE__ = {}`)
+	var extractErr *ExtractError
+	if !errors.As(err, &extractErr) || extractErr.Step != StepDecodeState || strings.Contains(err.Error(), "VM") {
+		t.Errorf("want state error without VM fallback, got %v", err)
+	}
+}
+
+func TestExtractStateJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		script     string
+		want       string
+		wantErr    bool
+		wantNoJSON bool
+	}{
+		{name: "trailing statements", script: `var a = 1; window.__PRELOADED_STATE__ = {"a": [1, "}"]}; window.b = {};`, want: `{"a": [1, "}"]}`},
+		{name: "no whitespace", script: `window.__PRELOADED_STATE__={"a":1}`, want: `{"a":1}`},
+		{name: "missing", script: `window.foo = {}`, wantErr: true},
+		{name: "not assigned", script: `console.log(window.__PRELOADED_STATE__)`, wantErr: true},
+		{name: "not JSON", script: `window.__PRELOADED_STATE__ = {a: 1}`, wantErr: true, wantNoJSON: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractStateJSON(tt.script)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got %v", tt.wantErr, err)
+			}
+			if errors.Is(err, errStateNotJSON) != tt.wantNoJSON {
+				t.Errorf("want errStateNotJSON %t, got %v", tt.wantNoJSON, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	script := loadScript(b)
+
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := New(script); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("vm", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			stateJSON, err := evaluateStateJS(script)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err = json.Unmarshal(stateJSON, new(Metadata)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
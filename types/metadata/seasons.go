@@ -0,0 +1,152 @@
+package metadata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// seasonIDs contains the ID Ubisoft uses for every season up to FallbackVersion, keyed by slug.
+// IDs are listed explicitly since they are assigned in order of release rather than derived from the slug.
+var seasonIDs = map[string]int{
+	"Y1S0": 0, "Y1S1": 1, "Y1S2": 2, "Y1S3": 3, "Y1S4": 4,
+	"Y2S1": 5, "Y2S2": 6, "Y2S3": 7, "Y2S4": 8,
+	"Y3S1": 9, "Y3S2": 10, "Y3S3": 11, "Y3S4": 12,
+	"Y4S1": 13, "Y4S2": 14, "Y4S3": 15, "Y4S4": 16,
+	"Y5S1": 17, "Y5S2": 18, "Y5S3": 19, "Y5S4": 20,
+	"Y6S1": 21, "Y6S2": 22, "Y6S3": 23, "Y6S4": 24,
+	"Y7S1": 25, "Y7S2": 26, "Y7S3": 27, "Y7S4": 28,
+	"Y8S1": 29, "Y8S2": 30, "Y8S3": 31, "Y8S4": 32,
+	"Y9S1": 33,
+}
+
+// seasonsPerYear is the number of seasons per year assumed for seasons released after FallbackVersion.
+const seasonsPerYear = 4
+
+// SeasonID returns the ID Ubisoft uses for the season with slug (e.g. 30 for "Y8S2"), as in ranked.SeasonStats.SeasonID.
+// IDs of seasons up to FallbackVersion are taken from a table, IDs of later seasons are counted on from FallbackVersion
+// assuming four seasons per year, which needs a table entry once a year deviates from that.
+// Returns false if slug is not a valid season slug.
+func SeasonID(slug string) (int, bool) {
+	slug = strings.ToUpper(slug)
+	if id, ok := seasonIDs[slug]; ok {
+		return id, true
+	}
+
+	y, s, ok := parseSlug(slug)
+	if !ok || s < 1 || s > seasonsPerYear || y < lastYear || (y == lastYear && s <= lastSeason) {
+		// seasons up to FallbackVersion are listed in the table
+		return 0, false
+	}
+	return seasonIDs[FallbackVersion] + (y-lastYear)*seasonsPerYear + s - lastSeason, true
+}
+
+// lastYear and lastSeason are the year and season of FallbackVersion, the last season listed in seasonIDs.
+var lastYear, lastSeason, _ = parseSlug(FallbackVersion)
+
+// parseSlug returns year and season of an upper-case slug such as "Y8S2".
+func parseSlug(slug string) (year int, season int, ok bool) {
+	if n, err := fmt.Sscanf(slug, "Y%dS%d", &year, &season); err != nil || n != 2 || fmt.Sprintf("Y%dS%d", year, season) != slug {
+		return 0, 0, false
+	}
+	return year, season, true
+}
+
+// ID returns the ID of s, see SeasonID.
+func (s Season) ID() (int, bool) {
+	return SeasonID(s.Slug)
+}
+
+// seasonIndex contains the seasons of a Metadata with a valid ID, ordered by ID.
+type seasonIndex struct {
+	ids     []int
+	seasons []Season
+	skipped []string // slugs of the seasons left out
+}
+
+// newSeasonIndex indexes seasons by their ID.
+// A season is left out if its ID is unknown or already taken or if it does not start after the season preceding it by ID,
+// so that seasons with an unexpected slug cannot be mistaken for others.
+func newSeasonIndex(seasons []Season) *seasonIndex {
+	type entry struct {
+		id     int
+		season Season
+	}
+	index := new(seasonIndex)
+	entries := make([]entry, 0, len(seasons))
+	for _, season := range seasons {
+		if id, ok := season.ID(); ok {
+			entries = append(entries, entry{id: id, season: season})
+		} else {
+			index.skipped = append(index.skipped, season.Slug)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].id < entries[j].id
+	})
+
+	for _, e := range entries {
+		if n := len(index.seasons); n > 0 && (index.ids[n-1] == e.id || !e.season.StartDate.After(index.seasons[n-1].StartDate)) {
+			index.skipped = append(index.skipped, e.season.Slug)
+			continue
+		}
+		index.ids = append(index.ids, e.id)
+		index.seasons = append(index.seasons, e.season)
+	}
+	return index
+}
+
+// seasonIndex returns the index built when m was decoded, or builds it if m was created otherwise.
+func (m *Metadata) seasonIndex() *seasonIndex {
+	if m.index != nil {
+		return m.index
+	}
+	return newSeasonIndex(m.Seasons)
+}
+
+// SkippedSeasons returns the slugs of the seasons which cannot be looked up by ID, slug or time,
+// e.g. because their slug is invalid, duplicated or their start date conflicts with the seasons preceding them.
+func (m *Metadata) SkippedSeasons() []string {
+	return append([]string(nil), m.seasonIndex().skipped...)
+}
+
+// SeasonByID returns the season with the ID Ubisoft uses (e.g. 30 for "Y8S2"), such as ranked.SeasonStats.SeasonID.
+// Returns false if no such season is known.
+func (m *Metadata) SeasonByID(seasonID int) (Season, bool) {
+	index := m.seasonIndex()
+	i := sort.SearchInts(index.ids, seasonID)
+	if i == len(index.ids) || index.ids[i] != seasonID {
+		return Season{}, false
+	}
+	return index.seasons[i], true
+}
+
+// SeasonBySlug returns the season with slug (e.g. "Y8S2"), ignoring case.
+// Returns false if no such season is known.
+func (m *Metadata) SeasonBySlug(slug string) (Season, bool) {
+	id, ok := SeasonID(slug)
+	if !ok {
+		return Season{}, false
+	}
+	return m.SeasonByID(id)
+}
+
+// SeasonAt returns the season running at t, i.e. the last season starting at or before t.
+// Returns false if t is before the first known season.
+// Seasons released after the metadata was fetched are unknown, so for recent times the previous season might be returned.
+func (m *Metadata) SeasonAt(t time.Time) (Season, bool) {
+	seasons := m.seasonIndex().seasons
+	i := sort.Search(len(seasons), func(i int) bool {
+		return seasons[i].StartDate.After(t)
+	})
+	if i == 0 {
+		return Season{}, false
+	}
+	return seasons[i-1], true
+}
+
+// CurrentSeason returns the season running now, see SeasonAt.
+func (m *Metadata) CurrentSeason() (Season, bool) {
+	return m.SeasonAt(time.Now())
+}
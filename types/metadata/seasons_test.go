@@ -0,0 +1,132 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSeasonID(t *testing.T) {
+	tests := []struct {
+		slug   string
+		wantID int
+		wantOK bool
+	}{
+		{"Y1S0", 0, true},
+		{"Y1S1", 1, true},
+		{"Y8S2", 30, true},
+		{"y9s1", 33, true},
+		{"Y9S2", 34, true},
+		{"Y10S1", 37, true},
+		{"Y9S0", 0, false},
+		{"Y10S5", 0, false},
+		{"Y+10S1", 0, false},
+		{"Y2S0", 0, false},
+		{"Y8S5", 0, false},
+		{"Y0S1", 0, false},
+		{"Y8", 0, false},
+		{"S8Y2", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		id, ok := SeasonID(tt.slug)
+		if id != tt.wantID || ok != tt.wantOK {
+			t.Errorf("%s: want (%d, %t), got (%d, %t)", tt.slug, tt.wantID, tt.wantOK, id, ok)
+		}
+	}
+}
+
+func date(s string) time.Time {
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestSeasonLookup(t *testing.T) {
+	// out of order, with a gap, an invalid slug, a duplicate and a season starting before its predecessor
+	m := &Metadata{Seasons: []Season{
+		{Slug: "Y8S2", Name: "Dread Factor", StartDate: date("2023-05-30")},
+		{Slug: "Y1S0", Name: "Launch", StartDate: date("2015-12-01")},
+		{Slug: "Y8S1", Name: "Commanding Force", StartDate: date("2023-03-07")},
+		{Slug: "Y8S1", Name: "Duplicate", StartDate: date("2023-03-08")},
+		{Slug: "Y8S3", Name: "Misdated", StartDate: date("2023-01-01")},
+		{Slug: "Operation Health", Name: "Health", StartDate: date("2017-06-07")},
+	}}
+
+	for id, want := range map[int]string{0: "Launch", 29: "Commanding Force", 30: "Dread Factor"} {
+		if season, ok := m.SeasonByID(id); !ok || season.Name != want {
+			t.Errorf("SeasonByID(%d): want %s, got %+v", id, want, season)
+		}
+	}
+	for _, id := range []int{-1, 1, 31, 6, len(m.Seasons)} {
+		if season, ok := m.SeasonByID(id); ok {
+			t.Errorf("SeasonByID(%d): want no season, got %+v", id, season)
+		}
+	}
+	if slug := m.SeasonSlugFromID(len(m.Seasons)); slug != "" {
+		t.Errorf("want empty slug, got '%s'", slug)
+	}
+	if name := m.SeasonNameFromID(-1); name != "" {
+		t.Errorf("want empty name, got '%s'", name)
+	}
+
+	if season, ok := m.SeasonBySlug("y8s2"); !ok || season.Name != "Dread Factor" {
+		t.Errorf("SeasonBySlug: unexpected %+v", season)
+	}
+	if _, ok := m.SeasonBySlug("Y8S3"); ok {
+		t.Error("SeasonBySlug: misdated season should be unknown")
+	}
+	if skipped := m.SkippedSeasons(); !reflect.DeepEqual(skipped, []string{"Operation Health", "Y8S1", "Y8S3"}) {
+		t.Errorf("unexpected skipped seasons %v", skipped)
+	}
+
+	atTests := []struct {
+		t      time.Time
+		want   string
+		wantOK bool
+	}{
+		{date("2015-11-30"), "", false},
+		{date("2015-12-01"), "Launch", true},
+		{date("2023-03-06"), "Launch", true},
+		{date("2023-03-07"), "Commanding Force", true},
+		{date("2024-01-01"), "Dread Factor", true},
+	}
+	for _, tt := range atTests {
+		season, ok := m.SeasonAt(tt.t)
+		if season.Name != tt.want || ok != tt.wantOK {
+			t.Errorf("SeasonAt(%s): want (%s, %t), got (%s, %t)", tt.t.Format(time.DateOnly), tt.want, tt.wantOK, season.Name, ok)
+		}
+	}
+
+	// all seasons started in the past
+	if season, ok := m.CurrentSeason(); !ok || season.Name != "Dread Factor" {
+		t.Errorf("CurrentSeason: unexpected %+v", season)
+	}
+}
+
+func TestSeasonIDsMatchIndex(t *testing.T) {
+	// the glossary page lists all seasons, so their IDs match their position
+	m, err := New(loadScript(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, season := range m.Seasons {
+		if byID, ok := m.SeasonByID(i); !ok || byID.Slug != season.Slug {
+			t.Errorf("SeasonByID(%d): want %s, got %+v", i, season.Slug, byID)
+		}
+		if table, ok := seasonIDs[season.Slug]; !ok || table != i {
+			t.Errorf("season ID table: want %s = %d, got %d (%t)", season.Slug, i, table, ok)
+		}
+	}
+	if skipped := m.SkippedSeasons(); len(skipped) != 0 {
+		t.Errorf("want no skipped seasons, got %v", skipped)
+	}
+
+	// the index is built when decoding
+	m.Seasons = nil
+	if season, ok := m.SeasonByID(30); !ok || season.Slug != "Y8S2" {
+		t.Errorf("want indexed season Y8S2, got %+v", season)
+	}
+}
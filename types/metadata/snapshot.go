@@ -0,0 +1,97 @@
+package metadata
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotVersion is the version of the snapshot format written by SaveTo.
+const snapshotVersion = 1
+
+type snapshotJSON struct {
+	Version   int       `json:"version"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	Seasons   []Season  `json:"seasons"`
+}
+
+// FallbackVersion is the season (slug) of the most recent season contained in the snapshot returned by Fallback.
+const FallbackVersion = "Y9S1"
+
+//go:embed snapshot.json
+var fallbackSnapshot []byte
+
+// SaveTo writes m as a snapshot to the file at path, which can be loaded by LoadFrom.
+// The snapshot is written to a temporary file which then replaces path, so an existing snapshot is never left partially written.
+func (m *Metadata) SaveTo(path string) (err error) {
+	var data []byte
+	data, err = json.Marshal(snapshotJSON{
+		Version:   snapshotVersion,
+		FetchedAt: m.FetchedAt,
+		Seasons:   m.Seasons,
+	})
+	if err != nil {
+		return
+	}
+
+	var file *os.File
+	file, err = os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(file.Name()))
+		}
+	}()
+	if err = file.Chmod(0644); err != nil {
+		err = errors.Join(err, file.Close())
+		return
+	}
+	if _, err = file.Write(data); err != nil {
+		err = errors.Join(err, file.Close())
+		return
+	}
+	if err = file.Close(); err != nil {
+		return
+	}
+	err = os.Rename(file.Name(), path)
+	return
+}
+
+// LoadFrom loads a snapshot written by SaveTo from the file at path.
+func LoadFrom(path string) (*Metadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnapshot(data)
+}
+
+// Fallback returns the snapshot embedded in the library, containing all seasons up to FallbackVersion.
+// It can be used if the glossary page is unavailable, but does not contain seasons released after FallbackVersion.
+// The snapshot is compiled by hand rather than captured from the glossary page, its FetchedAt is the start of FallbackVersion.
+// It only contains the ranks of Ranked 2.0 seasons (Y7S4 onwards), which share the same table of rank points;
+// the ranks of earlier seasons are unknown and empty.
+func Fallback() *Metadata {
+	m, err := decodeSnapshot(fallbackSnapshot)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded metadata snapshot: %v", err))
+	}
+	return m
+}
+
+func decodeSnapshot(data []byte) (*Metadata, error) {
+	var snapshot snapshotJSON
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("could not decode metadata snapshot: %w", err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported metadata snapshot version %d", snapshot.Version)
+	}
+	return &Metadata{Seasons: snapshot.Seasons, FetchedAt: snapshot.FetchedAt, index: newSeasonIndex(snapshot.Seasons)}, nil
+}
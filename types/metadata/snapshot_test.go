@@ -0,0 +1,81 @@
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	m, err := New(loadScript(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.FetchedAt = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	path := filepath.Join(t.TempDir(), "metadata.json")
+	if err = m.SaveTo(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, m) {
+		t.Errorf("loaded snapshot differs from saved metadata")
+	}
+
+	// overwriting replaces the snapshot without leaving temporary files behind
+	if err = m.SaveTo(path); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("want only the snapshot file, got %v", entries)
+	}
+}
+
+func TestSnapshotVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metadata.json")
+	if err := os.WriteFile(path, []byte(`{"version": 2, "seasons": []}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFrom(path); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
+
+func TestFallback(t *testing.T) {
+	fallback := Fallback()
+	if n := len(fallback.Seasons); n == 0 || fallback.Seasons[n-1].Slug != FallbackVersion {
+		t.Fatalf("fallback should end with %s", FallbackVersion)
+	}
+	if fallback.FetchedAt.IsZero() {
+		t.Error("fallback should have FetchedAt")
+	}
+	for i := 1; i < len(fallback.Seasons); i++ {
+		if !fallback.Seasons[i].StartDate.After(fallback.Seasons[i-1].StartDate) {
+			t.Errorf("season %s should start after %s", fallback.Seasons[i].Slug, fallback.Seasons[i-1].Slug)
+		}
+	}
+
+	// only Ranked 2.0 seasons have known ranks
+	for _, season := range fallback.Seasons {
+		id, _ := season.ID()
+		if ranked2 := id >= 28; ranked2 != (len(season.Ranks) > 0) {
+			t.Errorf("season %s: unexpected ranks %v", season.Slug, season.Ranks)
+		}
+	}
+
+	// the fallback needs to agree with the glossary page for all seasons it contains
+	m, err := New(loadScript(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, season := range m.Seasons {
+		if !reflect.DeepEqual(fallback.Seasons[i], season) {
+			t.Errorf("fallback differs from glossary page for season %s", season.Slug)
+		}
+	}
+}
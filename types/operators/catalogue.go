@@ -0,0 +1,85 @@
+package operators
+
+// CatalogueVersion is the season (slug) the catalogue reflects, i.e. it contains all operators released up to that season
+// with their roles at that time. It changes whenever operators are added or their data changes.
+const CatalogueVersion = "Y9S1"
+
+// launchSeason is the release season of the operators available at launch.
+const launchSeason = "Y1S0"
+
+var catalogue = []Operator{
+	// attackers
+	{Name: "Sledge", Side: Attacker, Roles: []Role{Breach, FrontLine}, CTU: "SAS", ReleaseSeason: launchSeason},
+	{Name: "Thatcher", Side: Attacker, Roles: []Role{AntiGadget, Support}, CTU: "SAS", ReleaseSeason: launchSeason},
+	{Name: "Ash", Side: Attacker, Roles: []Role{Breach, FrontLine}, CTU: "FBI SWAT", ReleaseSeason: launchSeason},
+	{Name: "Thermite", Side: Attacker, Roles: []Role{Breach, Support}, CTU: "FBI SWAT", ReleaseSeason: launchSeason},
+	{Name: "Twitch", Side: Attacker, Roles: []Role{AntiGadget, Intel}, CTU: "GIGN", ReleaseSeason: launchSeason},
+	{Name: "Montagne", Side: Attacker, Roles: []Role{FrontLine, Support}, CTU: "GIGN", ReleaseSeason: launchSeason},
+	{Name: "Glaz", Side: Attacker, Roles: []Role{MapControl, Support}, CTU: "Spetsnaz", ReleaseSeason: launchSeason},
+	{Name: "Fuze", Side: Attacker, Roles: []Role{AntiGadget, Breach}, CTU: "Spetsnaz", ReleaseSeason: launchSeason},
+	{Name: "Blitz", Side: Attacker, Roles: []Role{FrontLine}, CTU: "GSG 9", ReleaseSeason: launchSeason},
+	{Name: "IQ", Side: Attacker, Roles: []Role{Intel, Support}, CTU: "GSG 9", ReleaseSeason: launchSeason},
+	{Name: "Buck", Side: Attacker, Roles: []Role{Breach, FrontLine}, CTU: "JTF2", ReleaseSeason: "Y1S1"},
+	{Name: "Blackbeard", Side: Attacker, Roles: []Role{MapControl}, CTU: "Navy SEAL", ReleaseSeason: "Y1S2"},
+	{Name: "Capitão", Side: Attacker, Roles: []Role{MapControl, Support}, CTU: "BOPE", ReleaseSeason: "Y1S3"},
+	{Name: "Hibana", Side: Attacker, Roles: []Role{Breach, FrontLine}, CTU: "SAT", ReleaseSeason: "Y1S4"},
+	{Name: "Jackal", Side: Attacker, Roles: []Role{Intel, MapControl}, CTU: "GEO", ReleaseSeason: "Y2S1"},
+	{Name: "Ying", Side: Attacker, Roles: []Role{FrontLine, Support}, CTU: "SDU", ReleaseSeason: "Y2S3"},
+	{Name: "Zofia", Side: Attacker, Roles: []Role{AntiGadget, Breach}, CTU: "GROM", ReleaseSeason: "Y2S4"},
+	{Name: "Dokkaebi", Side: Attacker, Roles: []Role{Intel, MapControl}, CTU: "707th SMB", ReleaseSeason: "Y2S4"},
+	{Name: "Lion", Side: Attacker, Roles: []Role{Intel, MapControl}, CTU: "CBRN", ReleaseSeason: "Y3S1"},
+	{Name: "Finka", Side: Attacker, Roles: []Role{Support}, CTU: "CBRN", ReleaseSeason: "Y3S1"},
+	{Name: "Maverick", Side: Attacker, Roles: []Role{Breach, FrontLine}, CTU: "GSUTR", ReleaseSeason: "Y3S3"},
+	{Name: "Nomad", Side: Attacker, Roles: []Role{MapControl, Support}, CTU: "GIGR", ReleaseSeason: "Y3S4"},
+	{Name: "Gridlock", Side: Attacker, Roles: []Role{MapControl, Support}, CTU: "SASR", ReleaseSeason: "Y4S1"},
+	{Name: "Nøkk", Side: Attacker, Roles: []Role{FrontLine, Intel}, CTU: "Jaeger Corps", ReleaseSeason: "Y4S2"},
+	{Name: "Amaru", Side: Attacker, Roles: []Role{FrontLine}, CTU: "APCA", ReleaseSeason: "Y4S3"},
+	{Name: "Kali", Side: Attacker, Roles: []Role{AntiGadget, Support}, CTU: "NIGHTHAVEN", ReleaseSeason: "Y4S4"},
+	{Name: "Iana", Side: Attacker, Roles: []Role{FrontLine, Intel}, CTU: "REU", ReleaseSeason: "Y5S1"},
+	{Name: "Ace", Side: Attacker, Roles: []Role{Breach, Support}, CTU: "NIGHTHAVEN", ReleaseSeason: "Y5S2"},
+	{Name: "Zero", Side: Attacker, Roles: []Role{Intel, Support}, CTU: "ROS", ReleaseSeason: "Y5S3"},
+	{Name: "Flores", Side: Attacker, Roles: []Role{AntiGadget, Support}, ReleaseSeason: "Y6S1"},
+	{Name: "Osa", Side: Attacker, Roles: []Role{FrontLine, Support}, ReleaseSeason: "Y6S3"},
+	{Name: "Sens", Side: Attacker, Roles: []Role{MapControl, Support}, ReleaseSeason: "Y7S2"},
+	{Name: "Grim", Side: Attacker, Roles: []Role{Intel, MapControl}, ReleaseSeason: "Y7S3"},
+	{Name: "Brava", Side: Attacker, Roles: []Role{AntiGadget, Intel}, ReleaseSeason: "Y8S1"},
+	{Name: "Ram", Side: Attacker, Roles: []Role{Breach, MapControl}, ReleaseSeason: "Y8S3"},
+	{Name: "Deimos", Side: Attacker, Roles: []Role{FrontLine, Intel}, ReleaseSeason: "Y9S1"},
+
+	// defenders
+	{Name: "Smoke", Side: Defender, Roles: []Role{AntiEntry, Trapper}, CTU: "SAS", ReleaseSeason: launchSeason},
+	{Name: "Mute", Side: Defender, Roles: []Role{AntiGadget, Intel}, CTU: "SAS", ReleaseSeason: launchSeason},
+	{Name: "Castle", Side: Defender, Roles: []Role{CrowdControl, Support}, CTU: "FBI SWAT", ReleaseSeason: launchSeason},
+	{Name: "Pulse", Side: Defender, Roles: []Role{AntiEntry, Intel}, CTU: "FBI SWAT", ReleaseSeason: launchSeason},
+	{Name: "Doc", Side: Defender, Roles: []Role{Support}, CTU: "GIGN", ReleaseSeason: launchSeason},
+	{Name: "Rook", Side: Defender, Roles: []Role{Support}, CTU: "GIGN", ReleaseSeason: launchSeason},
+	{Name: "Kapkan", Side: Defender, Roles: []Role{AntiEntry, Trapper}, CTU: "Spetsnaz", ReleaseSeason: launchSeason},
+	{Name: "Tachanka", Side: Defender, Roles: []Role{AntiEntry, CrowdControl}, CTU: "Spetsnaz", ReleaseSeason: launchSeason},
+	{Name: "Jäger", Side: Defender, Roles: []Role{AntiGadget}, CTU: "GSG 9", ReleaseSeason: launchSeason},
+	{Name: "Bandit", Side: Defender, Roles: []Role{AntiGadget}, CTU: "GSG 9", ReleaseSeason: launchSeason},
+	{Name: "Frost", Side: Defender, Roles: []Role{AntiEntry, Trapper}, CTU: "JTF2", ReleaseSeason: "Y1S1"},
+	{Name: "Valkyrie", Side: Defender, Roles: []Role{Intel}, CTU: "Navy SEAL", ReleaseSeason: "Y1S2"},
+	{Name: "Caveira", Side: Defender, Roles: []Role{AntiEntry, Intel}, CTU: "BOPE", ReleaseSeason: "Y1S3"},
+	{Name: "Echo", Side: Defender, Roles: []Role{CrowdControl, Intel}, CTU: "SAT", ReleaseSeason: "Y1S4"},
+	{Name: "Mira", Side: Defender, Roles: []Role{Intel, Support}, CTU: "GEO", ReleaseSeason: "Y2S1"},
+	{Name: "Lesion", Side: Defender, Roles: []Role{Intel, Trapper}, CTU: "SDU", ReleaseSeason: "Y2S3"},
+	{Name: "Ela", Side: Defender, Roles: []Role{CrowdControl, Trapper}, CTU: "GROM", ReleaseSeason: "Y2S3"},
+	{Name: "Vigil", Side: Defender, Roles: []Role{AntiEntry, Intel}, CTU: "707th SMB", ReleaseSeason: "Y2S4"},
+	{Name: "Maestro", Side: Defender, Roles: []Role{AntiEntry, Intel}, CTU: "GIS", ReleaseSeason: "Y3S2"},
+	{Name: "Alibi", Side: Defender, Roles: []Role{CrowdControl, Intel}, CTU: "GIS", ReleaseSeason: "Y3S2"},
+	{Name: "Clash", Side: Defender, Roles: []Role{AntiEntry, CrowdControl}, CTU: "GSUTR", ReleaseSeason: "Y3S3"},
+	{Name: "Kaid", Side: Defender, Roles: []Role{AntiGadget}, CTU: "GIGR", ReleaseSeason: "Y3S4"},
+	{Name: "Mozzie", Side: Defender, Roles: []Role{AntiGadget, Intel}, CTU: "SASR", ReleaseSeason: "Y4S1"},
+	{Name: "Warden", Side: Defender, Roles: []Role{Intel}, CTU: "Secret Service", ReleaseSeason: "Y4S2"},
+	{Name: "Goyo", Side: Defender, Roles: []Role{AntiEntry, CrowdControl}, CTU: "FES", ReleaseSeason: "Y4S3"},
+	{Name: "Wamai", Side: Defender, Roles: []Role{AntiGadget}, CTU: "NIGHTHAVEN", ReleaseSeason: "Y4S4"},
+	{Name: "Oryx", Side: Defender, Roles: []Role{AntiEntry}, ReleaseSeason: "Y5S1"},
+	{Name: "Melusi", Side: Defender, Roles: []Role{CrowdControl, Intel}, CTU: "Inkaba Task Force", ReleaseSeason: "Y5S2"},
+	{Name: "Aruni", Side: Defender, Roles: []Role{AntiGadget, CrowdControl}, ReleaseSeason: "Y5S4"},
+	{Name: "Thunderbird", Side: Defender, Roles: []Role{Support}, ReleaseSeason: "Y6S2"},
+	{Name: "Thorn", Side: Defender, Roles: []Role{AntiEntry, Trapper}, ReleaseSeason: "Y6S4"},
+	{Name: "Azami", Side: Defender, Roles: []Role{Support}, ReleaseSeason: "Y7S1"},
+	{Name: "Solis", Side: Defender, Roles: []Role{AntiEntry, Intel}, ReleaseSeason: "Y7S4"},
+	{Name: "Fenrir", Side: Defender, Roles: []Role{AntiEntry, Trapper}, ReleaseSeason: "Y8S2"},
+	{Name: "Tubarão", Side: Defender, Roles: []Role{AntiEntry, Support}, ReleaseSeason: "Y8S4"},
+}
@@ -0,0 +1,179 @@
+// Package operators contains a static catalogue of operators,
+// allowing to group operator stats by side, role, unit or release season.
+package operators
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/stnokott/r6api/internal/keys"
+	"github.com/stnokott/r6api/types/stats"
+)
+
+type Side string
+
+const (
+	Attacker Side = "attacker"
+	Defender Side = "defender"
+)
+
+// Role is an operator role as shown in-game.
+type Role string
+
+const (
+	AntiEntry    Role = "Anti-Entry"
+	AntiGadget   Role = "Anti-Gadget"
+	Breach       Role = "Breach"
+	CrowdControl Role = "Crowd Control"
+	FrontLine    Role = "Front Line"
+	Intel        Role = "Intel"
+	MapControl   Role = "Map Control"
+	Support      Role = "Support"
+	Trapper      Role = "Trapper"
+)
+
+// Operator is a single operator of the catalogue.
+// The catalogue is compiled by hand from the operator pages of the game as of CatalogueVersion, no API provides this data.
+type Operator struct {
+	Name  string // canonical name, e.g. "Jäger"
+	Side  Side
+	Roles []Role // roles shown in the operator selection of the game
+	// CTU is the counter-terrorism unit of the operator's biography (e.g. "GSG 9"), empty for operators without one (e.g. Oryx).
+	// Operators released from Y6S1 (Flores) on are presented without a CTU in-game, so it is empty for all of them.
+	CTU           string
+	ReleaseSeason string // slug of the season the operator was released in, "Y1S0" for operators available at launch
+}
+
+// IconSlug returns the name of the operator in lower case without diacritics or punctuation (e.g. "jager" for Jäger),
+// as used in icon file names.
+func (o Operator) IconSlug() string {
+	return strings.ToLower(Normalize(o.Name))
+}
+
+var byKey = map[string]int{}
+
+func init() {
+	for i, o := range catalogue {
+		byKey[Normalize(o.Name)] = i
+	}
+}
+
+// Normalize returns the key of an operator name, which ignores case, diacritics, whitespace and punctuation,
+// e.g. "JAGER" for "Jäger".
+func Normalize(name string) string {
+	return keys.Normalize(name)
+}
+
+// suffix matches a suffix of an operator name in stats responses, i.e. a parenthesized qualifier (e.g. " (Elite)") or an index (e.g. "_2").
+var suffix = regexp.MustCompile(`\s*(\([^()]*\)|_\d+)$`)
+
+// Lookup returns the operator for name, which may be a name as contained in stats responses.
+// Besides ignoring case, diacritics and punctuation (see Normalize), suffixes following the operator name
+// are ignored if they are parenthesized (e.g. "Ash (Elite)") or an index (e.g. "Ash_2").
+// Returns false if no operator of the catalogue matches.
+func Lookup(name string) (Operator, bool) {
+	for {
+		if i, ok := byKey[Normalize(name)]; ok {
+			return copyOperator(catalogue[i]), true
+		}
+		stripped := suffix.ReplaceAllString(name, "")
+		if stripped == name {
+			return Operator{}, false
+		}
+		name = stripped
+	}
+}
+
+// All returns all operators of the catalogue, attackers first, each side ordered by release.
+func All() []Operator {
+	result := make([]Operator, len(catalogue))
+	for i, o := range catalogue {
+		result[i] = copyOperator(o)
+	}
+	return result
+}
+
+func copyOperator(o Operator) Operator {
+	o.Roles = append([]Role(nil), o.Roles...)
+	return o
+}
+
+// Entry contains the stats of a single operator.
+type Entry struct {
+	Operator Operator
+	Stats    stats.DetailedStats
+}
+
+// Join relates the stats in s to the operators of the catalogue, returning one entry per operator ordered by operator name.
+// Stats of multiple keys belonging to the same operator (e.g. because of suffixes) are combined with stats.Aggregate.
+// Keys not matching any operator (such as "Recruit") are returned in unknown, ordered alphabetically.
+// The entry "All" containing the total of all operators is ignored.
+func Join(s stats.NamedTeamRoleStats) (entries []Entry, unknown []string) {
+	perOperator := map[string][]stats.DetailedStats{}
+	operators := map[string]Operator{}
+	for _, key := range keys.Sorted(s) {
+		if key == "All" {
+			continue
+		}
+		o, ok := Lookup(key)
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		operators[o.Name] = o
+		perOperator[o.Name] = append(perOperator[o.Name], s[key])
+	}
+
+	for name, detailed := range perOperator {
+		entry := Entry{Operator: operators[name], Stats: detailed[0]}
+		if len(detailed) > 1 {
+			entry.Stats = stats.Aggregate(detailed...)
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Operator.Name < entries[j].Operator.Name
+	})
+	return
+}
+
+// GroupBy combines the stats of all operators in s sharing the same group, as returned by groups, with stats.Aggregate.
+// An operator with multiple groups contributes to each of them, keys not matching any operator are ignored.
+func GroupBy(s stats.NamedTeamRoleStats, groups func(Operator) []string) map[string]stats.DetailedStats {
+	perGroup := map[string][]stats.DetailedStats{}
+	entries, _ := Join(s)
+	for _, entry := range entries {
+		for _, group := range groups(entry.Operator) {
+			perGroup[group] = append(perGroup[group], entry.Stats)
+		}
+	}
+	result := make(map[string]stats.DetailedStats, len(perGroup))
+	for group, detailed := range perGroup {
+		result[group] = stats.Aggregate(detailed...)
+	}
+	return result
+}
+
+// GroupByRole combines the stats of all operators in s per role, see GroupBy.
+func GroupByRole(s stats.NamedTeamRoleStats) map[Role]stats.DetailedStats {
+	grouped := GroupBy(s, func(o Operator) []string {
+		roles := make([]string, len(o.Roles))
+		for i, role := range o.Roles {
+			roles[i] = string(role)
+		}
+		return roles
+	})
+	result := make(map[Role]stats.DetailedStats, len(grouped))
+	for role, detailed := range grouped {
+		result[Role(role)] = detailed
+	}
+	return result
+}
+
+// GroupByReleaseSeason combines the stats of all operators in s per release season (e.g. "Y5S2"), see GroupBy.
+func GroupByReleaseSeason(s stats.NamedTeamRoleStats) map[string]stats.DetailedStats {
+	return GroupBy(s, func(o Operator) []string {
+		return []string{o.ReleaseSeason}
+	})
+}
@@ -0,0 +1,109 @@
+package operators
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stnokott/r6api/internal/cataloguetest"
+	"github.com/stnokott/r6api/types/stats"
+)
+
+func TestCatalogue(t *testing.T) {
+	var slugs []string
+	for _, o := range All() {
+		if o.Side != Attacker && o.Side != Defender {
+			t.Errorf("%s has invalid side '%s'", o.Name, o.Side)
+		}
+		if len(o.Roles) == 0 || o.ReleaseSeason == "" {
+			t.Errorf("%s is incomplete: %+v", o.Name, o)
+		}
+		var year, season int
+		if _, err := fmt.Sscanf(o.ReleaseSeason, "Y%dS%d", &year, &season); err != nil {
+			t.Errorf("%s has invalid release season '%s'", o.Name, o.ReleaseSeason)
+		}
+		// operators are presented without CTU from Y6 on
+		if year >= 6 && o.CTU != "" {
+			t.Errorf("%s released in %s has unexpected CTU '%s'", o.Name, o.ReleaseSeason, o.CTU)
+		}
+		slugs = append(slugs, o.IconSlug())
+	}
+	cataloguetest.CheckUnique(t, "icon slug", slugs)
+}
+
+func TestLookup(t *testing.T) {
+	cataloguetest.RunLookups(t, func(name string) (string, bool) {
+		o, ok := Lookup(name)
+		return o.Name, ok
+	},
+		cataloguetest.LookupCase{Name: "Ash", Want: "Ash"},
+		cataloguetest.LookupCase{Name: "JÄGER", Want: "Jäger"},
+		cataloguetest.LookupCase{Name: "Jager", Want: "Jäger"},
+		cataloguetest.LookupCase{Name: "nokk", Want: "Nøkk"},
+		cataloguetest.LookupCase{Name: "Capitao", Want: "Capitão"},
+		cataloguetest.LookupCase{Name: "Ash (Elite)", Want: "Ash"},
+		cataloguetest.LookupCase{Name: "Tachanka_2", Want: "Tachanka"},
+		cataloguetest.LookupCase{Name: "Ash_2 (Elite)", Want: "Ash"},
+		cataloguetest.LookupCase{Name: "Ash Thermite"},
+		cataloguetest.LookupCase{Name: "Ash-Elite"},
+		cataloguetest.LookupCase{Name: "(Elite)"},
+		cataloguetest.LookupCase{Name: "Recruit"},
+		cataloguetest.LookupCase{Name: ""},
+	)
+
+	if o, _ := Lookup("Jäger"); o.IconSlug() != "jager" {
+		t.Errorf("want icon slug jager, got %s", o.IconSlug())
+	}
+}
+
+func TestJoin(t *testing.T) {
+	detailed := cataloguetest.Detailed
+	s := stats.NamedTeamRoleStats{
+		"Ash":         detailed(10, 10),
+		"Ash (Elite)": detailed(5, 5),
+		"Thermite":    detailed(2, 4),
+		"Recruit":     detailed(1, 1),
+		"All":         detailed(18, 20),
+	}
+
+	entries, unknown := Join(s)
+	if len(entries) != 2 || entries[0].Operator.Name != "Ash" || entries[0].Stats.Kills != 15 || entries[1].Stats.Kills != 2 {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+	if len(unknown) != 1 || unknown[0] != "Recruit" {
+		t.Errorf("want unknown [Recruit], got %v", unknown)
+	}
+
+	byRole := GroupByRole(s)
+	if got := byRole[Breach]; got.Kills != 17 || got.RoundsPlayed != 19 {
+		t.Errorf("unexpected breach stats %+v", got)
+	}
+	if got := byRole[Support]; got.Kills != 2 {
+		t.Errorf("want 2 support kills, got %d", got.Kills)
+	}
+	if _, ok := byRole[Trapper]; ok {
+		t.Error("unplayed roles should be omitted")
+	}
+
+	if got := GroupByReleaseSeason(s)["Y1S0"]; got.Kills != 17 {
+		t.Errorf("want 17 kills for launch operators, got %d", got.Kills)
+	}
+}
+
+func TestJoinFixture(t *testing.T) {
+	data, err := os.ReadFile("../../r6apitest/testdata/operators.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := new(stats.OperatorStats)
+	if err = s.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	entries, unknown := Join(s.All.All)
+	if len(entries) != 3 {
+		t.Errorf("want 3 operators, got %+v", entries)
+	}
+	if len(unknown) != 1 || unknown[0] != "Recruit" {
+		t.Errorf("want unknown [Recruit], got %v", unknown)
+	}
+}
@@ -0,0 +1,61 @@
+package ranked
+
+import (
+	"encoding/json"
+
+	"github.com/stnokott/r6api/internal/ratio"
+)
+
+// Metrics contains performance figures derived from the raw counts in SeasonStats.
+// Every ratio is 0 if its denominator is 0, e.g. a player without any matches has a win rate of 0.
+// The KD is an exception, see SeasonStats.KD.
+type Metrics struct {
+	KD            float64
+	WinRate       float64
+	KillsPerMatch float64
+}
+
+// KD returns the ratio of kills to deaths.
+// As in-game, 0 deaths are treated as 1, i.e. the KD of a player without deaths equals their kills.
+func (s SeasonStats) KD() float64 {
+	return ratio.KD(s.Kills, s.Deaths)
+}
+
+// WinRate returns the ratio of wins to matches played (wins + losses + abandons).
+func (s SeasonStats) WinRate() float64 {
+	return ratio.Of(s.Wins, s.MatchesPlayed())
+}
+
+// KillsPerMatch returns the average number of kills per match played (wins + losses + abandons).
+func (s SeasonStats) KillsPerMatch() float64 {
+	return ratio.Of(s.Kills, s.MatchesPlayed())
+}
+
+// MatchesPlayed returns the number of matches played, including abandoned matches.
+func (s SeasonStats) MatchesPlayed() int {
+	return s.Wins + s.Losses + s.Abandons
+}
+
+// Metrics returns all derived metrics at once.
+func (s SeasonStats) Metrics() Metrics {
+	return Metrics{
+		KD:            s.KD(),
+		WinRate:       s.WinRate(),
+		KillsPerMatch: s.KillsPerMatch(),
+	}
+}
+
+// seasonStatsFields has the same fields as SeasonStats, but none of its methods.
+// It is used to marshal SeasonStats without recursing into its own MarshalJSON.
+type seasonStatsFields SeasonStats
+
+// MarshalJSON includes the derived metrics in the JSON output.
+func (s SeasonStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		seasonStatsFields
+		Metrics Metrics
+	}{
+		seasonStatsFields: seasonStatsFields(s),
+		Metrics:           s.Metrics(),
+	})
+}
@@ -0,0 +1,54 @@
+package ranked
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats SeasonStats
+		want  Metrics
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "no deaths",
+			stats: SeasonStats{Kills: 5, Wins: 1},
+			want:  Metrics{KD: 5, WinRate: 1, KillsPerMatch: 5},
+		},
+		{
+			name:  "abandons",
+			stats: SeasonStats{Kills: 8, Deaths: 4, Wins: 1, Losses: 2, Abandons: 1},
+			want:  Metrics{KD: 2, WinRate: 0.25, KillsPerMatch: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.Metrics(); got != tt.want {
+				t.Errorf("want %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSeasonStatsMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(SeasonStats{SeasonID: 30, Kills: 6, Deaths: 3, Wins: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		SeasonID int
+		Kills    int
+		Metrics  Metrics
+	}
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := Metrics{KD: 2, WinRate: 1, KillsPerMatch: 3}
+	if got.SeasonID != 30 || got.Kills != 6 || got.Metrics != want {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
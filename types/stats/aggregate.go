@@ -0,0 +1,158 @@
+package stats
+
+// Aggregate combines multiple DetailedStats (e.g. of several operators, maps or seasons) into one.
+//
+// Counts are summed up, while ratios are averaged with the weight of the underlying denominator:
+//   - per-round values (e.g. KillsPerRound, RoundsWithKOST, DistancePerRound) are weighted by RoundsPlayed
+//   - HeadshotPercentage is weighted by Kills
+//   - per-match values (TimeAlivePerMatch, TimeDeadPerMatch) are weighted by MatchesPlayed
+//
+// Season is only retained if all entries belong to the same season.
+// Entries without any rounds (or kills, or matches, respectively) therefore do not influence the averages.
+// Note that when combining operators, match counts are summed per operator, so a match in which multiple operators
+// were played is counted multiple times (see the MatchesPlayed, MatchesWon and MatchesLost fields).
+func Aggregate(stats ...DetailedStats) DetailedStats {
+	var (
+		result DetailedStats
+
+		killsPerRound        weightedMean
+		headshotPercentage   weightedMean
+		roundsSurvived       weightedMean
+		roundsWithKill       weightedMean
+		roundsWithMultikill  weightedMean
+		roundsWithAce        weightedMean
+		roundsWithClutch     weightedMean
+		roundsWithKOST       weightedMean
+		roundsWithEntryDeath weightedMean
+		roundsWithEntryKill  weightedMean
+		distancePerRound     weightedMean
+		timeAlivePerMatch    weightedMean
+		timeDeadPerMatch     weightedMean
+	)
+
+	for i, s := range stats {
+		if i == 0 {
+			result.Season = s.Season
+		} else if result.Season != s.Season {
+			result.Season = ""
+		}
+		result.Headshots += s.Headshots
+		result.Kills += s.Kills
+		result.RoundsPlayed += s.RoundsPlayed
+		result.RoundsWon += s.RoundsWon
+		result.RoundsLost += s.RoundsLost
+		result.MatchesPlayed += s.MatchesPlayed
+		result.MatchesWon += s.MatchesWon
+		result.MatchesLost += s.MatchesLost
+		result.MinutesPlayed += s.MinutesPlayed
+		result.Assists += s.Assists
+		result.Deaths += s.Deaths
+		result.MeleeKills += s.MeleeKills
+		result.TeamKills += s.TeamKills
+		result.EntryDeaths += s.EntryDeaths
+		result.EntryDeathTrades += s.EntryDeathTrades
+		result.EntryKills += s.EntryKills
+		result.EntryKillTrades += s.EntryKillTrades
+		result.Trades += s.Trades
+		result.Revives += s.Revives
+		result.DistanceTotal += s.DistanceTotal
+
+		killsPerRound.add(s.KillsPerRound, s.RoundsPlayed)
+		headshotPercentage.add(s.HeadshotPercentage, s.Kills)
+		roundsSurvived.add(s.RoundsSurvived, s.RoundsPlayed)
+		roundsWithKill.add(s.RoundsWithKill, s.RoundsPlayed)
+		roundsWithMultikill.add(s.RoundsWithMultikill, s.RoundsPlayed)
+		roundsWithAce.add(s.RoundsWithAce, s.RoundsPlayed)
+		roundsWithClutch.add(s.RoundsWithClutch, s.RoundsPlayed)
+		roundsWithKOST.add(s.RoundsWithKOST, s.RoundsPlayed)
+		roundsWithEntryDeath.add(s.RoundsWithEntryDeath, s.RoundsPlayed)
+		roundsWithEntryKill.add(s.RoundsWithEntryKill, s.RoundsPlayed)
+		distancePerRound.add(s.DistancePerRound, s.RoundsPlayed)
+		timeAlivePerMatch.add(s.TimeAlivePerMatch, s.MatchesPlayed)
+		timeDeadPerMatch.add(s.TimeDeadPerMatch, s.MatchesPlayed)
+	}
+
+	result.KillsPerRound = killsPerRound.value()
+	result.HeadshotPercentage = headshotPercentage.value()
+	result.RoundsSurvived = roundsSurvived.value()
+	result.RoundsWithKill = roundsWithKill.value()
+	result.RoundsWithMultikill = roundsWithMultikill.value()
+	result.RoundsWithAce = roundsWithAce.value()
+	result.RoundsWithClutch = roundsWithClutch.value()
+	result.RoundsWithKOST = roundsWithKOST.value()
+	result.RoundsWithEntryDeath = roundsWithEntryDeath.value()
+	result.RoundsWithEntryKill = roundsWithEntryKill.value()
+	result.DistancePerRound = distancePerRound.value()
+	result.TimeAlivePerMatch = timeAlivePerMatch.value()
+	result.TimeDeadPerMatch = timeDeadPerMatch.value()
+	return result
+}
+
+// combine returns the only entry of stats as-is, thus retaining the exact values reported by the API, or aggregates multiple entries.
+func combine(stats []DetailedStats) DetailedStats {
+	if len(stats) == 1 {
+		return stats[0]
+	}
+	return Aggregate(stats...)
+}
+
+// AggregateWeapons combines multiple WeaponNamedStats (e.g. of several weapons or seasons) into one
+// the same way Aggregate does for DetailedStats.
+// Their Seasons are combined as well, aggregating the entries of the same season.
+func AggregateWeapons(stats ...WeaponNamedStats) WeaponNamedStats {
+	var (
+		result              WeaponNamedStats
+		roundsWithKill      weightedMean
+		roundsWithMultikill weightedMean
+		headshotPercentage  weightedMean
+	)
+	for i, s := range stats {
+		if i == 0 {
+			result.Season = s.Season
+		} else if result.Season != s.Season {
+			result.Season = ""
+		}
+		result.Headshots += s.Headshots
+		result.Kills += s.Kills
+		result.RoundsPlayed += s.RoundsPlayed
+		result.RoundsWon += s.RoundsWon
+		result.RoundsLost += s.RoundsLost
+
+		roundsWithKill.add(s.RoundsWithKill, s.RoundsPlayed)
+		roundsWithMultikill.add(s.RoundsWithMultikill, s.RoundsPlayed)
+		headshotPercentage.add(s.HeadshotPercentage, s.Kills)
+
+		for season, seasonStats := range s.Seasons {
+			if result.Seasons == nil {
+				result.Seasons = map[string]WeaponNamedStats{}
+			}
+			if existing, ok := result.Seasons[season]; ok {
+				seasonStats = AggregateWeapons(existing, seasonStats)
+			}
+			result.Seasons[season] = seasonStats
+		}
+	}
+	result.RoundsWithKill = roundsWithKill.value()
+	result.RoundsWithMultikill = roundsWithMultikill.value()
+	result.HeadshotPercentage = headshotPercentage.value()
+	return result
+}
+
+// weightedMean accumulates values with integer weights.
+type weightedMean struct {
+	sum    float64
+	weight int
+}
+
+func (m *weightedMean) add(value float64, weight int) {
+	m.sum += value * float64(weight)
+	m.weight += weight
+}
+
+// value returns the weighted mean of all added values or 0 if the total weight is 0.
+func (m *weightedMean) value() float64 {
+	if m.weight == 0 {
+		return 0
+	}
+	return m.sum / float64(m.weight)
+}
@@ -0,0 +1,261 @@
+package stats
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+)
+
+// sharedTestdata contains the fixtures served by the r6apitest fake server, which are shared with this package.
+const sharedTestdata = "../../r6apitest/testdata/"
+
+// loadFixture decodes the fixture at path, relative to this package, into dst.
+func loadFixture(t *testing.T, path string, dst Provider) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+	if err = json.Unmarshal(data, dst); err != nil {
+		t.Fatalf("could not unmarshal fixture: %v", err)
+	}
+}
+
+func assertFloat(t *testing.T, field string, want float64, got float64) {
+	t.Helper()
+	if math.Abs(want-got) > 1e-9 {
+		t.Errorf("%s: want %f, got %f", field, want, got)
+	}
+}
+
+func newTestStats(rounds int, kills int, matches int, killsPerRound float64, headshotPercentage float64, timeAlivePerMatch float64) DetailedStats {
+	s := DetailedStats{
+		KillsPerRound:      killsPerRound,
+		HeadshotPercentage: headshotPercentage,
+		TimeAlivePerMatch:  timeAlivePerMatch,
+	}
+	s.RoundsPlayed = rounds
+	s.Kills = kills
+	s.MatchesPlayed = matches
+	return s
+}
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		name                  string
+		input                 []DetailedStats
+		wantRounds            int
+		wantKills             int
+		wantMatches           int
+		wantKillsPerRound     float64
+		wantHeadshotPct       float64
+		wantTimeAlivePerMatch float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:                  "single",
+			input:                 []DetailedStats{newTestStats(10, 5, 2, 0.5, 0.4, 120)},
+			wantRounds:            10,
+			wantKills:             5,
+			wantMatches:           2,
+			wantKillsPerRound:     0.5,
+			wantHeadshotPct:       0.4,
+			wantTimeAlivePerMatch: 120,
+		},
+		{
+			name: "weighted by rounds, kills and matches",
+			input: []DetailedStats{
+				newTestStats(30, 30, 1, 1.0, 0.5, 100),
+				newTestStats(10, 10, 3, 1.0/3, 0.1, 200),
+			},
+			wantRounds:            40,
+			wantKills:             40,
+			wantMatches:           4,
+			wantKillsPerRound:     (30*1.0 + 10*1.0/3) / 40,
+			wantHeadshotPct:       (30*0.5 + 10*0.1) / 40,
+			wantTimeAlivePerMatch: (1*100 + 3*200) / 4.0,
+		},
+		{
+			name: "entries without rounds are ignored in averages",
+			input: []DetailedStats{
+				newTestStats(20, 10, 4, 0.5, 0.3, 90),
+				newTestStats(0, 0, 0, 0, 0, 0),
+			},
+			wantRounds:            20,
+			wantKills:             10,
+			wantMatches:           4,
+			wantKillsPerRound:     0.5,
+			wantHeadshotPct:       0.3,
+			wantTimeAlivePerMatch: 90,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Aggregate(tt.input...)
+			if got.RoundsPlayed != tt.wantRounds {
+				t.Errorf("RoundsPlayed: want %d, got %d", tt.wantRounds, got.RoundsPlayed)
+			}
+			if got.Kills != tt.wantKills {
+				t.Errorf("Kills: want %d, got %d", tt.wantKills, got.Kills)
+			}
+			if got.MatchesPlayed != tt.wantMatches {
+				t.Errorf("MatchesPlayed: want %d, got %d", tt.wantMatches, got.MatchesPlayed)
+			}
+			assertFloat(t, "KillsPerRound", tt.wantKillsPerRound, got.KillsPerRound)
+			assertFloat(t, "HeadshotPercentage", tt.wantHeadshotPct, got.HeadshotPercentage)
+			assertFloat(t, "TimeAlivePerMatch", tt.wantTimeAlivePerMatch, got.TimeAlivePerMatch)
+		})
+	}
+}
+
+func TestOperatorStatsAllRow(t *testing.T) {
+	s := new(OperatorStats)
+	loadFixture(t, sharedTestdata+"operators.json", s)
+
+	tests := []struct {
+		name                    string
+		teamRole                NamedTeamRoleStats
+		wantRounds              int
+		wantKills               int
+		wantHeadshots           int
+		wantKillsPerRound       float64
+		wantHeadshotPct         float64
+		wantRoundsSurvived      float64
+		wantRoundsWithKill      float64
+		wantRoundsWithMultikill float64
+		wantTimeAlivePerMatch   float64
+		wantDistancePerRound    float64
+	}{
+		{
+			name:                    "all/attack",
+			teamRole:                s.All.Attack,
+			wantRounds:              50,
+			wantKills:               35,
+			wantHeadshots:           16,
+			wantKillsPerRound:       0.7,
+			wantHeadshotPct:         16.0 / 35,
+			wantRoundsSurvived:      0.44,
+			wantRoundsWithKill:      0.52,
+			wantRoundsWithMultikill: 0.14,
+			wantTimeAlivePerMatch:   2700.0 / 11,
+			wantDistancePerRound:    46,
+		},
+		{
+			name:                    "all/all",
+			teamRole:                s.All.All,
+			wantRounds:              75,
+			wantKills:               55,
+			wantHeadshots:           28,
+			wantKillsPerRound:       (0.75*40 + 0.5*10 + 0.8*25) / 75,
+			wantHeadshotPct:         (0.5*30 + 0.2*5 + 0.6*20) / 55,
+			wantRoundsSurvived:      (0.5*40 + 0.2*10 + 0.6*25) / 75,
+			wantRoundsWithKill:      (0.55*40 + 0.4*10 + 0.6*25) / 75,
+			wantRoundsWithMultikill: (0.15*40 + 0.1*10 + 0.2*25) / 75,
+			wantTimeAlivePerMatch:   (300*8 + 100*3 + 250*5) / 16.0,
+			wantDistancePerRound:    (50*40 + 30*10 + 20*25) / 75.0,
+		},
+		{
+			name:                    "ranked/defence",
+			teamRole:                s.Ranked.Defence,
+			wantRounds:              25,
+			wantKills:               20,
+			wantHeadshots:           12,
+			wantKillsPerRound:       0.8,
+			wantHeadshotPct:         0.6,
+			wantRoundsSurvived:      0.6,
+			wantRoundsWithKill:      0.6,
+			wantRoundsWithMultikill: 0.2,
+			wantTimeAlivePerMatch:   250,
+			wantDistancePerRound:    20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.teamRole["All"]
+			if !ok {
+				t.Fatal("missing aggregated entry 'All'")
+			}
+			if got.RoundsPlayed != tt.wantRounds {
+				t.Errorf("RoundsPlayed: want %d, got %d", tt.wantRounds, got.RoundsPlayed)
+			}
+			if got.Kills != tt.wantKills {
+				t.Errorf("Kills: want %d, got %d", tt.wantKills, got.Kills)
+			}
+			if got.Headshots != tt.wantHeadshots {
+				t.Errorf("Headshots: want %d, got %d", tt.wantHeadshots, got.Headshots)
+			}
+			assertFloat(t, "KillsPerRound", tt.wantKillsPerRound, got.KillsPerRound)
+			assertFloat(t, "HeadshotPercentage", tt.wantHeadshotPct, got.HeadshotPercentage)
+			assertFloat(t, "RoundsSurvived", tt.wantRoundsSurvived, got.RoundsSurvived)
+			assertFloat(t, "RoundsWithKill", tt.wantRoundsWithKill, got.RoundsWithKill)
+			assertFloat(t, "RoundsWithMultikill", tt.wantRoundsWithMultikill, got.RoundsWithMultikill)
+			assertFloat(t, "TimeAlivePerMatch", tt.wantTimeAlivePerMatch, got.TimeAlivePerMatch)
+			assertFloat(t, "DistancePerRound", tt.wantDistancePerRound, got.DistancePerRound)
+		})
+	}
+}
+
+func TestOperatorStatsSeasons(t *testing.T) {
+	s := new(OperatorStats)
+	loadFixture(t, "testdata/operators_seasonal.json", s)
+
+	tests := []struct {
+		name              string
+		teamRole          NamedTeamRoleStats
+		wantSeason        string
+		wantRounds        int
+		wantKills         int
+		wantKillsPerRound float64
+	}{
+		{
+			name:              "total",
+			teamRole:          s.Ranked.Attack,
+			wantSeason:        "",
+			wantRounds:        60,
+			wantKills:         40,
+			wantKillsPerRound: (0.5*20 + 0.75*40) / 60,
+		},
+		{
+			name:              "Y8S1",
+			teamRole:          s.Ranked.Seasons["Y8S1"].Attack,
+			wantSeason:        "Y8S1",
+			wantRounds:        20,
+			wantKills:         10,
+			wantKillsPerRound: 0.5,
+		},
+		{
+			name:              "Y8S2",
+			teamRole:          s.Ranked.Seasons["Y8S2"].Attack,
+			wantSeason:        "Y8S2",
+			wantRounds:        40,
+			wantKills:         30,
+			wantKillsPerRound: 0.75,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"Ash", "All"} {
+				got, ok := tt.teamRole[name]
+				if !ok {
+					t.Fatalf("missing entry '%s'", name)
+				}
+				if got.Season != tt.wantSeason {
+					t.Errorf("%s Season: want '%s', got '%s'", name, tt.wantSeason, got.Season)
+				}
+				if got.RoundsPlayed != tt.wantRounds {
+					t.Errorf("%s RoundsPlayed: want %d, got %d", name, tt.wantRounds, got.RoundsPlayed)
+				}
+				if got.Kills != tt.wantKills {
+					t.Errorf("%s Kills: want %d, got %d", name, tt.wantKills, got.Kills)
+				}
+				assertFloat(t, name+" KillsPerRound", tt.wantKillsPerRound, got.KillsPerRound)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package stats
+
+import "encoding/json"
+
+/******************
+Decoding toolkit
+*******************/
+
+// TeamRoleEntry is a single entry of a team role in a stats response in the Ubisoft API format, e.g. the stats of one operator.
+// It allows building custom providers for aggregations or views not covered by this package, see WalkTeamRoles.
+type TeamRoleEntry struct {
+	GameMode    GameMode
+	TeamRole    TeamRole
+	Type        string // type of the entry (e.g. "Seasonal"), determining how it is decoded, empty for weapon entries
+	StatsType   string // aggregation of the entry (e.g. "operators"), may be empty
+	StatsDetail string // name of the entry (e.g. the operator or weapon name), empty for aggregations without names such as "summary"
+	Season      string // season slug (e.g. "Y8S2"), empty if unknown
+	WeaponSlot  string // weapon slot ("primaryWeapons" or "secondaryWeapons"), only set for weapon entries
+	WeaponType  string // weapon type (e.g. "Assault Rifle"), only set for weapon entries
+
+	raw   json.RawMessage
+	value any
+}
+
+// DetailedStats converts the entry to DetailedStats.
+// Returns false if the entry does not contain detailed stats, e.g. because it contains a moving trend or is of unknown type.
+func (e TeamRoleEntry) DetailedStats() (DetailedStats, bool) {
+	data, ok := e.value.(*ubiDetailedStatsJSON)
+	if !ok {
+		return DetailedStats{}, false
+	}
+	return *newDetailedStats(data), true
+}
+
+// MovingTrend converts the entry to a MovingTrend, interpreting the positions of its points according to trendType.
+// Returns false if the entry does not contain a moving trend or its positions do not match trendType.
+func (e TeamRoleEntry) MovingTrend(trendType TrendType) (*MovingTrend, bool) {
+	data, ok := e.value.(*ubiMovingTrendJSON)
+	if !ok {
+		return nil, false
+	}
+	trend, err := newMovingTrendStats(data, trendType)
+	return trend, err == nil
+}
+
+// WeaponStats converts the entry to WeaponNamedStats.
+// Returns false if the entry is not part of a weapons response.
+func (e TeamRoleEntry) WeaponStats() (WeaponNamedStats, bool) {
+	data, ok := e.value.(*ubiWeaponStatsJSON)
+	if !ok {
+		return WeaponNamedStats{}, false
+	}
+	return newWeaponNamedStats(data), true
+}
+
+// Known returns true if the type of the entry is known to this package, i.e. it can be converted with DetailedStats, MovingTrend or WeaponStats.
+func (e TeamRoleEntry) Known() bool {
+	return e.value != nil
+}
+
+// Raw returns the JSON of the entry as contained in the response, e.g. to access fields not decoded by this package.
+func (e TeamRoleEntry) Raw() json.RawMessage {
+	return e.raw
+}
+
+// WalkTeamRoles decodes data, which needs to be a stats response in the Ubisoft API format, calling fn for every team role entry.
+// It decodes with the same types as the providers of this package.
+// Game modes are walked in the order all, casual, unranked, ranked, team roles in the order all, Attacker, Defender.
+// Game modes of a type unknown to this package are skipped, entries of an unknown type are walked without being converted (see TeamRoleEntry.Known).
+// Weapon responses are walked per weapon, see TeamRoleEntry.WeaponStats.
+// Walking stops at the first error returned by fn, which is then returned.
+func WalkTeamRoles(data []byte, fn func(TeamRoleEntry) error) error {
+	var response ubiStatsResponseJSON
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	gameModes, gameModeJSONs := response.ProfileData[response.UserID].Platforms.PC.GameModes.byGameMode()
+	for i, gameModeJSON := range gameModeJSONs {
+		if gameModeJSON == nil {
+			continue
+		}
+		var err error
+		switch jsn := gameModeJSON.Value.(type) {
+		case *ubiTeamRolesJSON:
+			err = walkTeamRoleEntries(gameModes[i], jsn, fn)
+		case *ubiGameModeWeaponsJSON:
+			err = walkWeaponEntries(gameModes[i], jsn, fn)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTeamRoleEntries(gameMode GameMode, jsn *ubiTeamRolesJSON, fn func(TeamRoleEntry) error) error {
+	teamRoles, entries := jsn.byTeamRole()
+	for i, teamRoleEntries := range entries {
+		for _, data := range *teamRoleEntries {
+			entry := TeamRoleEntry{
+				GameMode: gameMode,
+				TeamRole: teamRoles[i],
+				Type:     string(data.Type),
+				raw:      data.raw,
+			}
+			if data.StatsType != nil {
+				entry.StatsType = *data.StatsType
+			}
+			switch value := data.Value.(type) {
+			case *ubiDetailedStatsJSON:
+				entry.Season = value.ubiSeasonInfo.slug()
+				if value.StatsDetail != nil {
+					entry.StatsDetail = *value.StatsDetail
+				}
+				entry.value = value
+			case *ubiMovingTrendJSON:
+				entry.value = value
+			}
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func walkWeaponEntries(gameMode GameMode, jsn *ubiGameModeWeaponsJSON, fn func(TeamRoleEntry) error) error {
+	teamRoles, teamRoleJSONs := jsn.byTeamRole()
+	for i, teamRoleJSON := range teamRoleJSONs {
+		if teamRoleJSON == nil {
+			continue
+		}
+		weaponSlots := []string{"primaryWeapons", "secondaryWeapons"}
+		for j, weaponSlot := range []*ubiWeaponTypesJSON{teamRoleJSON.WeaponSlots.Primary, teamRoleJSON.WeaponSlots.Secondary} {
+			if weaponSlot == nil {
+				continue
+			}
+			for _, weaponType := range weaponSlot.WeaponTypes {
+				for k := range weaponType.Weapons {
+					weapon := &weaponType.Weapons[k]
+					entry := TeamRoleEntry{
+						GameMode:    gameMode,
+						TeamRole:    teamRoles[i],
+						StatsType:   "weapons",
+						StatsDetail: weapon.WeaponName,
+						Season:      weapon.ubiSeasonInfo.slug(),
+						WeaponSlot:  weaponSlots[j],
+						WeaponType:  weaponType.WeaponTypeName,
+						raw:         weapon.raw,
+						value:       weapon,
+					}
+					if err := fn(entry); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
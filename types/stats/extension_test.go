@@ -0,0 +1,152 @@
+package stats_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stnokott/r6api/types/stats"
+)
+
+// attackerStats is a custom provider aggregating all attacking operators per game mode.
+type attackerStats struct {
+	PerGameMode map[stats.GameMode]stats.DetailedStats
+}
+
+func (s *attackerStats) AggregationType() string {
+	return "operators"
+}
+
+func (s *attackerStats) ViewType() string {
+	return "seasonal"
+}
+
+func (s *attackerStats) UnmarshalJSON(data []byte) error {
+	perGameMode := map[stats.GameMode][]stats.DetailedStats{}
+	err := stats.WalkTeamRoles(data, func(entry stats.TeamRoleEntry) error {
+		if entry.TeamRole != stats.ATTACKER {
+			return nil
+		}
+		detailed, ok := entry.DetailedStats()
+		if !ok {
+			return fmt.Errorf("unexpected entry of type %s", entry.Type)
+		}
+		perGameMode[entry.GameMode] = append(perGameMode[entry.GameMode], detailed)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.PerGameMode = map[stats.GameMode]stats.DetailedStats{}
+	for gameMode, entries := range perGameMode {
+		s.PerGameMode[gameMode] = stats.Aggregate(entries...)
+	}
+	return nil
+}
+
+func ExampleWalkTeamRoles() {
+	data, _ := os.ReadFile("../../r6apitest/testdata/operators.json")
+
+	s := new(attackerStats)
+	if err := s.UnmarshalJSON(data); err != nil {
+		panic(err)
+	}
+	fmt.Println(s.PerGameMode[stats.ALL].Kills)
+	// Output: 35
+}
+
+func TestWalkTeamRoles(t *testing.T) {
+	data, err := os.ReadFile("testdata/operators_seasonal.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []stats.TeamRoleEntry
+	if err = stats.WalkTeamRoles(data, func(entry stats.TeamRoleEntry) error {
+		entries = append(entries, entry)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("no entries walked")
+	}
+
+	first := entries[0]
+	if first.GameMode != stats.RANKED || first.TeamRole != stats.ALL_ROLES {
+		t.Errorf("want first entry of game mode ranked and team role all, got %s/%s", first.GameMode, first.TeamRole)
+	}
+	if first.StatsType != "operators" || first.StatsDetail == "" || first.Season == "" {
+		t.Errorf("unexpected entry metadata: %+v", first)
+	}
+	if !first.Known() || len(first.Raw()) == 0 {
+		t.Error("entry should be known and contain raw JSON")
+	}
+	if _, ok := first.MovingTrend(stats.TrendByMatch); ok {
+		t.Error("detailed stats entry should not convert to moving trend")
+	}
+
+	// entries converted with the toolkit should equal the ones decoded by the built-in provider
+	operators := new(stats.OperatorStats)
+	if err = operators.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.GameMode != stats.RANKED || entry.TeamRole != stats.ATTACKER {
+			continue
+		}
+		detailed, ok := entry.DetailedStats()
+		if !ok {
+			t.Fatalf("entry %s cannot be converted to detailed stats", entry.StatsDetail)
+		}
+		if want := operators.Ranked.Seasons[entry.Season].Attack[entry.StatsDetail]; detailed != want {
+			t.Errorf("%s in %s: want %+v, got %+v", entry.StatsDetail, entry.Season, want, detailed)
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	if err = stats.WalkTeamRoles(data, func(stats.TeamRoleEntry) error {
+		calls++
+		return stop
+	}); !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("want walking to stop after first error, got %v after %d calls", err, calls)
+	}
+}
+
+func TestWalkTeamRolesWeapons(t *testing.T) {
+	data, err := os.ReadFile("../../r6apitest/testdata/weapons.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weapons := new(stats.WeaponStats)
+	if err = weapons.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+
+	walked := 0
+	if err = stats.WalkTeamRoles(data, func(entry stats.TeamRoleEntry) error {
+		walked++
+		named, ok := entry.WeaponStats()
+		if !ok || !entry.Known() || len(entry.Raw()) == 0 {
+			t.Fatalf("entry %s should be a known weapon entry with raw JSON", entry.StatsDetail)
+		}
+		if _, ok = entry.DetailedStats(); ok {
+			t.Error("weapon entry should not convert to detailed stats")
+		}
+		if entry.GameMode != stats.ALL || entry.TeamRole != stats.ALL_ROLES || entry.WeaponSlot != "primaryWeapons" {
+			return nil
+		}
+		if want := weapons.All.All.PrimaryWeapons[entry.WeaponType][entry.StatsDetail]; !reflect.DeepEqual(named, want) {
+			t.Errorf("%s: want %+v, got %+v", entry.StatsDetail, want, named)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if walked == 0 {
+		t.Error("no weapon entries walked")
+	}
+}
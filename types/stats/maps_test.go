@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// editMapsFixture returns the maps fixture after applying edit to the team roles of the ranked game mode.
+func editMapsFixture(t *testing.T, edit func(teamRoles map[string]any)) []byte {
+	t.Helper()
+	data, err := os.ReadFile(sharedTestdata + "maps.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response map[string]any
+	if err = json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	for _, profile := range response["profileData"].(map[string]any) {
+		gameModes := profile.(map[string]any)["platforms"].(map[string]any)["PC"].(map[string]any)["gameModes"].(map[string]any)
+		edit(gameModes["ranked"].(map[string]any)["teamRoles"].(map[string]any))
+	}
+	if data, err = json.Marshal(response); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestMapStatsTeamRoles(t *testing.T) {
+	tests := []struct {
+		name        string
+		edit        func(teamRoles map[string]any)
+		wantAttack  bool
+		wantDefence bool
+		wantErr     bool
+	}{
+		{
+			name:        "all team roles",
+			edit:        func(map[string]any) {},
+			wantAttack:  true,
+			wantDefence: true,
+		},
+		{
+			name: "attack only",
+			edit: func(teamRoles map[string]any) {
+				delete(teamRoles, string(DEFENDER))
+			},
+			wantAttack: true,
+		},
+		{
+			name: "no team roles",
+			edit: func(teamRoles map[string]any) {
+				for teamRole := range teamRoles {
+					delete(teamRoles, teamRole)
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := new(MapStats)
+			err := json.Unmarshal(editMapsFixture(t, tt.edit), s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			clubhouse, ok := (*s.Ranked)["CLUBHOUSE"]
+			if !ok {
+				t.Fatal("missing map CLUBHOUSE")
+			}
+			if clubhouse.Kills != 25 || clubhouse.MatchesPlayed != 6 {
+				t.Errorf("unexpected stats of all team roles: %+v", clubhouse.DetailedStats)
+			}
+			if (clubhouse.Attack != nil) != tt.wantAttack {
+				t.Errorf("want attack stats %t, got %+v", tt.wantAttack, clubhouse.Attack)
+			}
+			if (clubhouse.Defence != nil) != tt.wantDefence {
+				t.Errorf("want defence stats %t, got %+v", tt.wantDefence, clubhouse.Defence)
+			}
+		})
+	}
+}
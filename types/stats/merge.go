@@ -0,0 +1,218 @@
+package stats
+
+import "fmt"
+
+// Merger should be implemented by providers whose stats can be combined across multiple requests, e.g. for multiple seasons.
+type Merger interface {
+	Provider
+	// Merge adds the stats of other (which needs to be of the same type) to this instance.
+	// other should not be used anymore afterwards since parts of it might be reused.
+	Merge(other Provider) error
+}
+
+func (l *statsLoader[TGameMode, TJSON]) merge(other *statsLoader[TGameMode, TJSON], mergeGameMode func(dst *TGameMode, src *TGameMode)) {
+	dstGameModes := []**TGameMode{&l.All, &l.Casual, &l.Unranked, &l.Ranked}
+	srcGameModes := []*TGameMode{other.All, other.Casual, other.Unranked, other.Ranked}
+
+	for i, src := range srcGameModes {
+		if src == nil {
+			continue
+		}
+		if *dstGameModes[i] == nil {
+			*dstGameModes[i] = src
+			continue
+		}
+		mergeGameMode(*dstGameModes[i], src)
+	}
+	l.decodeIssues = append(l.decodeIssues, other.decodeIssues...)
+	l.unknownVariants = append(l.unknownVariants, other.unknownVariants...)
+	l.raw = append(l.raw, other.raw...)
+}
+
+func mergeTypeError(dst Provider, src Provider) error {
+	return fmt.Errorf("cannot merge %T into %T", src, dst)
+}
+
+func (s *SummarizedStats) Merge(other Provider) error {
+	o, ok := other.(*SummarizedStats)
+	if !ok {
+		return mergeTypeError(s, other)
+	}
+	s.merge(&o.statsLoader, mergeSummarizedGameModeStats)
+	return nil
+}
+
+func (s *OperatorStats) Merge(other Provider) error {
+	o, ok := other.(*OperatorStats)
+	if !ok {
+		return mergeTypeError(s, other)
+	}
+	s.merge(&o.statsLoader, mergeNamedTeamRoles)
+	return nil
+}
+
+func (s *MapStats) Merge(other Provider) error {
+	o, ok := other.(*MapStats)
+	if !ok {
+		return mergeTypeError(s, other)
+	}
+	s.merge(&o.statsLoader, func(dst *map[string]NamedMapStatDetails, src *map[string]NamedMapStatDetails) {
+		if *dst == nil {
+			*dst = map[string]NamedMapStatDetails{}
+		}
+		for name, srcStats := range *src {
+			dstStats, exists := (*dst)[name]
+			if !exists {
+				(*dst)[name] = srcStats
+				continue
+			}
+			dstStats.DetailedStats = Aggregate(dstStats.DetailedStats, srcStats.DetailedStats)
+			mergeDetailedStats(&dstStats.Attack, srcStats.Attack)
+			mergeDetailedStats(&dstStats.Defence, srcStats.Defence)
+			dstStats.Bombsites = mergeBombsiteGameModeStats(dstStats.Bombsites, srcStats.Bombsites)
+			(*dst)[name] = dstStats
+		}
+	})
+	return nil
+}
+
+func (s *WeaponStats) Merge(other Provider) error {
+	o, ok := other.(*WeaponStats)
+	if !ok {
+		return mergeTypeError(s, other)
+	}
+	s.merge(&o.statsLoader, func(dst *WeaponTeamRoles, src *WeaponTeamRoles) {
+		dstTeamRoles := []**WeaponTypes{&dst.All, &dst.Attack, &dst.Defence}
+		srcTeamRoles := []*WeaponTypes{src.All, src.Attack, src.Defence}
+		for i, srcTeamRole := range srcTeamRoles {
+			if srcTeamRole == nil {
+				continue
+			}
+			if *dstTeamRoles[i] == nil {
+				*dstTeamRoles[i] = srcTeamRole
+				continue
+			}
+			dstTeamRole := *dstTeamRoles[i]
+			dstTeamRole.PrimaryWeapons = mergeWeaponTypesMap(dstTeamRole.PrimaryWeapons, srcTeamRole.PrimaryWeapons)
+			dstTeamRole.SecondaryWeapons = mergeWeaponTypesMap(dstTeamRole.SecondaryWeapons, srcTeamRole.SecondaryWeapons)
+		}
+	})
+	return nil
+}
+
+func mergeSummarizedGameModeStats(dst *SummarizedGameModeStats, src *SummarizedGameModeStats) {
+	mergeDetailedStats(&dst.All, src.All)
+	mergeDetailedStats(&dst.Attack, src.Attack)
+	mergeDetailedStats(&dst.Defence, src.Defence)
+	dst.MatchesPlayed += src.MatchesPlayed
+	dst.MatchesWon += src.MatchesWon
+	dst.MatchesLost += src.MatchesLost
+
+	for season, srcSeasonStats := range src.Seasons {
+		if dst.Seasons == nil {
+			dst.Seasons = map[string]SummarizedGameModeStats{}
+		}
+		dstSeasonStats, exists := dst.Seasons[season]
+		if exists {
+			mergeSummarizedGameModeStats(&dstSeasonStats, &srcSeasonStats)
+		} else {
+			dstSeasonStats = srcSeasonStats
+		}
+		dst.Seasons[season] = dstSeasonStats
+	}
+}
+
+func mergeNamedTeamRoles(dst *NamedTeamRoles, src *NamedTeamRoles) {
+	dst.All = mergeNamedTeamRoleStats(dst.All, src.All)
+	dst.Attack = mergeNamedTeamRoleStats(dst.Attack, src.Attack)
+	dst.Defence = mergeNamedTeamRoleStats(dst.Defence, src.Defence)
+
+	for season, srcSeasonStats := range src.Seasons {
+		if dst.Seasons == nil {
+			dst.Seasons = map[string]NamedTeamRoles{}
+		}
+		dstSeasonStats, exists := dst.Seasons[season]
+		if exists {
+			mergeNamedTeamRoles(&dstSeasonStats, &srcSeasonStats)
+		} else {
+			dstSeasonStats = srcSeasonStats
+		}
+		dst.Seasons[season] = dstSeasonStats
+	}
+}
+
+func mergeDetailedStats(dst **DetailedStats, src *DetailedStats) {
+	if src == nil {
+		return
+	}
+	if *dst == nil {
+		*dst = src
+		return
+	}
+	merged := Aggregate(**dst, *src)
+	*dst = &merged
+}
+
+func mergeNamedTeamRoleStats(dst NamedTeamRoleStats, src NamedTeamRoleStats) NamedTeamRoleStats {
+	if dst == nil {
+		return src
+	}
+	for name, srcStats := range src {
+		if dstStats, exists := dst[name]; exists {
+			dst[name] = Aggregate(dstStats, srcStats)
+		} else {
+			dst[name] = srcStats
+		}
+	}
+	return dst
+}
+
+func mergeBombsiteGameModeStats(dst *BombsiteGamemodeStats, src *BombsiteGamemodeStats) *BombsiteGamemodeStats {
+	if dst == nil {
+		return src
+	}
+	if src == nil {
+		return dst
+	}
+	dst.All = mergeBombsiteTeamRoleStats(dst.All, src.All)
+	dst.Attack = mergeBombsiteTeamRoleStats(dst.Attack, src.Attack)
+	dst.Defence = mergeBombsiteTeamRoleStats(dst.Defence, src.Defence)
+	return dst
+}
+
+func mergeBombsiteTeamRoleStats(dst []BombsiteTeamRoleStats, src []BombsiteTeamRoleStats) []BombsiteTeamRoleStats {
+	indices := make(map[string]int, len(dst))
+	for i, bombsite := range dst {
+		indices[bombsite.Name] = i
+	}
+	for _, bombsite := range src {
+		if i, exists := indices[bombsite.Name]; exists {
+			dst[i].DetailedStats = Aggregate(dst[i].DetailedStats, bombsite.DetailedStats)
+		} else {
+			indices[bombsite.Name] = len(dst)
+			dst = append(dst, bombsite)
+		}
+	}
+	return dst
+}
+
+func mergeWeaponTypesMap(dst WeaponTypesMap, src WeaponTypesMap) WeaponTypesMap {
+	if dst == nil {
+		return src
+	}
+	for weaponType, srcWeapons := range src {
+		dstWeapons, exists := dst[weaponType]
+		if !exists {
+			dst[weaponType] = srcWeapons
+			continue
+		}
+		for weaponName, srcStats := range srcWeapons {
+			if dstStats, exists := dstWeapons[weaponName]; exists {
+				dstWeapons[weaponName] = AggregateWeapons(dstStats, srcStats)
+			} else {
+				dstWeapons[weaponName] = srcStats
+			}
+		}
+	}
+	return dst
+}
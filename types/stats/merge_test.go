@@ -0,0 +1,75 @@
+package stats
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		newStats func() Merger
+		kills    func(m Merger) int
+	}{
+		{
+			name:     "summary",
+			fixture:  "summary.json",
+			newStats: func() Merger { return new(SummarizedStats) },
+			kills:    func(m Merger) int { return m.(*SummarizedStats).Ranked.All.Kills },
+		},
+		{
+			name:     "operators",
+			fixture:  "operators.json",
+			newStats: func() Merger { return new(OperatorStats) },
+			kills:    func(m Merger) int { return m.(*OperatorStats).All.Attack["Ash"].Kills },
+		},
+		{
+			name:     "maps",
+			fixture:  "maps.json",
+			newStats: func() Merger { return new(MapStats) },
+			kills:    func(m Merger) int { return (*m.(*MapStats).Ranked)["CLUBHOUSE"].Attack.Kills },
+		},
+		{
+			name:     "weapons",
+			fixture:  "weapons.json",
+			newStats: func() Merger { return new(WeaponStats) },
+			kills:    func(m Merger) int { return m.(*WeaponStats).All.Attack.PrimaryWeapons["Assault Rifle"]["R4-C"].Kills },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst, src := tt.newStats(), tt.newStats()
+			loadFixture(t, sharedTestdata+tt.fixture, dst)
+			loadFixture(t, sharedTestdata+tt.fixture, src)
+			want := 2 * tt.kills(dst)
+			if want == 0 {
+				t.Fatal("fixture should contain kills")
+			}
+
+			if err := dst.Merge(src); err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.kills(dst); got != want {
+				t.Errorf("want %d kills, got %d", want, got)
+			}
+		})
+	}
+}
+
+func TestMergeMissingGameModes(t *testing.T) {
+	dst, src := new(SummarizedStats), new(SummarizedStats)
+	loadFixture(t, sharedTestdata+"summary.json", src)
+	wantKills := src.Ranked.All.Kills
+
+	if err := dst.Merge(src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Ranked == nil || dst.Ranked.All.Kills != wantKills {
+		t.Errorf("game modes missing in dst should be taken from src, got %+v", dst.Ranked)
+	}
+}
+
+func TestMergeTypeMismatch(t *testing.T) {
+	if err := new(SummarizedStats).Merge(new(OperatorStats)); err == nil {
+		t.Error("expected error when merging different providers")
+	}
+}
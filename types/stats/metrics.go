@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"encoding/json"
+
+	"github.com/stnokott/r6api/internal/ratio"
+)
+
+// Metrics contains performance figures derived from the raw counts in DetailedStats.
+// Every ratio is 0 if its denominator is 0, e.g. a player without any matches has a win rate of 0.
+// The KD is an exception, see DetailedStats.KD.
+type Metrics struct {
+	KD               float64 `json:"kd"`
+	WinRate          float64 `json:"winRate"`
+	RoundWinRate     float64 `json:"roundWinRate"`
+	HeadshotRatio    float64 `json:"headshotRatio"`
+	KillsPerMatch    float64 `json:"killsPerMatch"`
+	EntrySuccessRate float64 `json:"entrySuccessRate"`
+	TradeRatio       float64 `json:"tradeRatio"`
+	SurvivalRate     float64 `json:"survivalRate"`
+}
+
+// KD returns the ratio of kills to deaths.
+// As in-game, 0 deaths are treated as 1, i.e. the KD of a player without deaths equals their kills.
+func (s DetailedStats) KD() float64 {
+	return ratio.KD(s.Kills, s.Deaths)
+}
+
+// WinRate returns the ratio of matches won to matches played.
+func (s DetailedStats) WinRate() float64 {
+	return ratio.Of(s.MatchesWon, s.MatchesPlayed)
+}
+
+// RoundWinRate returns the ratio of rounds won to rounds played.
+func (s DetailedStats) RoundWinRate() float64 {
+	return ratio.Of(s.RoundsWon, s.RoundsPlayed)
+}
+
+// HeadshotRatio returns the ratio of headshots to kills.
+func (s DetailedStats) HeadshotRatio() float64 {
+	return ratio.Of(s.Headshots, s.Kills)
+}
+
+// KillsPerMatch returns the average number of kills per match played.
+func (s DetailedStats) KillsPerMatch() float64 {
+	return ratio.Of(s.Kills, s.MatchesPlayed)
+}
+
+// EntrySuccessRate returns the ratio of entry kills to entry duels (entry kills + entry deaths).
+func (s DetailedStats) EntrySuccessRate() float64 {
+	return ratio.Of(s.EntryKills, s.EntryKills+s.EntryDeaths)
+}
+
+// TradeRatio returns the ratio of trades to kills, i.e. the share of kills which traded a fallen teammate.
+func (s DetailedStats) TradeRatio() float64 {
+	return ratio.Of(s.Trades, s.Kills)
+}
+
+// SurvivalRate returns the ratio of rounds survived to rounds played, derived from deaths.
+func (s DetailedStats) SurvivalRate() float64 {
+	if s.RoundsPlayed == 0 {
+		return 0
+	}
+	return 1 - ratio.Of(s.Deaths, s.RoundsPlayed)
+}
+
+// Metrics returns all derived metrics at once.
+func (s DetailedStats) Metrics() Metrics {
+	return Metrics{
+		KD:               s.KD(),
+		WinRate:          s.WinRate(),
+		RoundWinRate:     s.RoundWinRate(),
+		HeadshotRatio:    s.HeadshotRatio(),
+		KillsPerMatch:    s.KillsPerMatch(),
+		EntrySuccessRate: s.EntrySuccessRate(),
+		TradeRatio:       s.TradeRatio(),
+		SurvivalRate:     s.SurvivalRate(),
+	}
+}
+
+// detailedStatsFields has the same fields as DetailedStats, but none of its methods.
+// It is used to marshal DetailedStats without recursing into its own MarshalJSON.
+type detailedStatsFields DetailedStats
+
+type detailedStatsJSON struct {
+	detailedStatsFields
+	Metrics Metrics `json:"metrics"`
+}
+
+func newDetailedStatsJSON(s DetailedStats) detailedStatsJSON {
+	return detailedStatsJSON{
+		detailedStatsFields: detailedStatsFields(s),
+		Metrics:             s.Metrics(),
+	}
+}
+
+// MarshalJSON includes the derived metrics in the JSON output.
+func (s DetailedStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newDetailedStatsJSON(s))
+}
+
+// MarshalJSON needs to be implemented explicitly since the one promoted from DetailedStats would drop all other fields.
+func (s NamedMapStatDetails) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		detailedStatsJSON
+		Attack    *DetailedStats         `json:"attack,omitempty"`
+		Defence   *DetailedStats         `json:"defence,omitempty"`
+		Bombsites *BombsiteGamemodeStats `json:"bombsites,omitempty"`
+	}{
+		detailedStatsJSON: newDetailedStatsJSON(s.DetailedStats),
+		Attack:            s.Attack,
+		Defence:           s.Defence,
+		Bombsites:         s.Bombsites,
+	})
+}
+
+// MarshalJSON needs to be implemented explicitly since the one promoted from DetailedStats would drop Name.
+func (s BombsiteTeamRoleStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		detailedStatsJSON
+		Name string `json:"name"`
+	}{
+		detailedStatsJSON: newDetailedStatsJSON(s.DetailedStats),
+		Name:              s.Name,
+	})
+}
@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	var s DetailedStats
+	s.Kills = 12
+	s.Deaths = 6
+	s.Headshots = 6
+	s.MatchesPlayed = 4
+	s.MatchesWon = 3
+	s.RoundsPlayed = 24
+	s.RoundsWon = 18
+	s.EntryKills = 3
+	s.EntryDeaths = 1
+	s.Trades = 2
+
+	m := s.Metrics()
+	assertFloat(t, "KD", 2, m.KD)
+	assertFloat(t, "WinRate", 0.75, m.WinRate)
+	assertFloat(t, "RoundWinRate", 0.75, m.RoundWinRate)
+	assertFloat(t, "HeadshotRatio", 0.5, m.HeadshotRatio)
+	assertFloat(t, "KillsPerMatch", 3, m.KillsPerMatch)
+	assertFloat(t, "EntrySuccessRate", 0.75, m.EntrySuccessRate)
+	assertFloat(t, "TradeRatio", 2.0/12, m.TradeRatio)
+	assertFloat(t, "SurvivalRate", 0.75, m.SurvivalRate)
+}
+
+func TestMetricsZeroDenominators(t *testing.T) {
+	if m := (DetailedStats{}).Metrics(); m != (Metrics{}) {
+		t.Errorf("want all metrics 0 without any stats, got %+v", m)
+	}
+
+	// a player without deaths ranks above a player with a KD below 1
+	var s DetailedStats
+	s.Kills = 3
+	assertFloat(t, "KD", 3, s.KD())
+	s.Kills = 0
+	assertFloat(t, "KD", 0, s.KD())
+}
+
+func TestDetailedStatsMarshalJSON(t *testing.T) {
+	var s NamedMapStatDetails
+	s.Kills = 10
+	s.Deaths = 5
+	s.Attack = new(DetailedStats)
+	s.Attack.Kills = 4
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Kills   int `json:"kills"`
+		Metrics struct {
+			KD float64 `json:"kd"`
+		} `json:"metrics"`
+		Attack struct {
+			Kills   int `json:"kills"`
+			Metrics struct {
+				KD float64 `json:"kd"`
+			} `json:"metrics"`
+		} `json:"attack"`
+	}
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Kills != 10 || got.Attack.Kills != 4 {
+		t.Errorf("fields missing in %s", data)
+	}
+	assertFloat(t, "kd", 2, got.Metrics.KD)
+	assertFloat(t, "attack kd", 4, got.Attack.Metrics.KD)
+
+	b := BombsiteTeamRoleStats{Name: "2F Gym / 2F Bedroom"}
+	b.Kills = 1
+	if data, err = json.Marshal(b); err != nil {
+		t.Fatal(err)
+	}
+	var gotBombsite struct {
+		Name    string         `json:"name"`
+		Metrics map[string]any `json:"metrics"`
+	}
+	if err = json.Unmarshal(data, &gotBombsite); err != nil {
+		t.Fatal(err)
+	}
+	if gotBombsite.Name != b.Name || gotBombsite.Metrics["kd"] != 1.0 {
+		t.Errorf("unexpected JSON %s", data)
+	}
+
+	// metrics are derived, so they are ignored when decoding
+	var decoded DetailedStats
+	if err = json.Unmarshal([]byte(`{"kills": 2, "metrics": {"kd": 99}}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	assertFloat(t, "decoded kd", 2, decoded.KD())
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"time"
 )
 
 type GameMode string
@@ -15,7 +18,16 @@ const (
 	RANKED   GameMode = "ranked"
 )
 
+type TeamRole string
+
+const (
+	ALL_ROLES TeamRole = "all"
+	ATTACKER  TeamRole = "Attacker"
+	DEFENDER  TeamRole = "Defender"
+)
+
 // Provider should be implemented by statistics structs to enable it to be unmarshalled properly into the corresponding struct.
+// Custom providers can decode the response with WalkTeamRoles.
 type Provider interface {
 	json.Unmarshaler
 	AggregationType() string // type of aggregation (e.g. "operators") to be used in URL query
@@ -27,25 +39,66 @@ type statsLoader[TGameMode any, TJSON any] struct {
 	Casual   *TGameMode
 	Unranked *TGameMode
 	Ranked   *TGameMode
+
+	decodeOptions   DecodeOptions
+	decodeIssues    []DecodeIssue
+	unknownVariants []UnknownVariant
+	raw             []json.RawMessage
+}
+
+// UnknownVariant is a part of a response whose game mode or team role type is not known to this package.
+// Such parts are skipped while decoding, the remaining response is decoded as usual.
+type UnknownVariant struct {
+	GameMode GameMode
+	TeamRole TeamRole // empty if the type of the whole game mode is unknown
+	Type     string
+	Raw      json.RawMessage
+}
+
+// UnknownVariants returns the parts of the last decoded response which were skipped due to their unknown type.
+func (l *statsLoader[TGameMode, TJSON]) UnknownVariants() []UnknownVariant {
+	return l.unknownVariants
 }
 
 func (l *statsLoader[TGameMode, TJSON]) loadRawStats(data []byte, dst Provider, loadTeamRoles func(*TJSON, *TGameMode) error) (err error) {
+	l.decodeIssues = nil
+	l.unknownVariants = nil
+	l.raw = nil
+	if l.decodeOptions.KeepRaw {
+		l.raw = []json.RawMessage{append(json.RawMessage(nil), data...)}
+	}
+	if l.decodeOptions.Strict {
+		if l.decodeIssues, err = checkSchema(data); err != nil {
+			return
+		}
+	}
+
 	var raw ubiStatsResponseJSON
 	if err = json.Unmarshal(data, &raw); err != nil {
 		return
 	}
 	root := raw.ProfileData[raw.UserID].Platforms.PC.GameModes
 
-	gameModeJSONs := []*ubiTypedGameModeJSON{root.StatsAll, root.StatsCasual, root.StatsUnranked, root.StatsRanked}
-	gameModes := []GameMode{ALL, CASUAL, UNRANKED, RANKED}
+	gameModes, gameModeJSONs := root.byGameMode()
 	for i, gameModeJSON := range gameModeJSONs {
 		if gameModeJSON == nil {
 			continue
 		}
+		if raw, isRaw := gameModeJSON.Value.(json.RawMessage); isRaw {
+			l.unknownVariants = append(l.unknownVariants, UnknownVariant{
+				GameMode: gameModes[i],
+				Type:     string(gameModeJSON.Type),
+				Raw:      raw,
+			})
+			continue
+		}
 		jsn, ok := gameModeJSON.Value.(*TJSON)
 		if !ok {
 			return fmt.Errorf("could not cast json (%T) to required struct (*%T)", gameModeJSON.Value, *new(TJSON))
 		}
+		if teamRolesJSON, hasTeamRoles := any(jsn).(*ubiTeamRolesJSON); hasTeamRoles {
+			l.unknownVariants = append(l.unknownVariants, teamRolesJSON.removeUnknown(gameModes[i])...)
+		}
 		stats := new(TGameMode)
 		switch gameModes[i] {
 		case ALL:
@@ -64,6 +117,9 @@ func (l *statsLoader[TGameMode, TJSON]) loadRawStats(data []byte, dst Provider,
 			return
 		}
 	}
+	if l.decodeOptions.FailOnIssues && len(l.decodeIssues) > 0 {
+		err = &DecodeError{Issues: l.decodeIssues}
+	}
 	return
 }
 
@@ -77,10 +133,13 @@ type SummarizedStats struct {
 }
 
 type SummarizedGameModeStats struct {
-	All     *DetailedStats
-	Attack  *DetailedStats
-	Defence *DetailedStats
+	All     *DetailedStats `json:"all,omitempty"`
+	Attack  *DetailedStats `json:"attack,omitempty"`
+	Defence *DetailedStats `json:"defence,omitempty"`
 	matchStats
+	// Seasons contains the same stats per season, keyed by season slug (e.g. "Y8S2").
+	// It is only populated if the response contains season information, entries do not contain Seasons themselves.
+	Seasons map[string]SummarizedGameModeStats `json:"seasons,omitempty"`
 }
 
 func (s *SummarizedStats) AggregationType() string {
@@ -103,19 +162,39 @@ func (s *SummarizedStats) loadTeamRole(jsn *ubiTeamRolesJSON, stats *SummarizedG
 		if len(inputTeamRole) == 0 {
 			continue
 		}
-		data, ok := inputTeamRole[0].Value.(*ubiDetailedStatsJSON)
-		if !ok {
-			err = fmt.Errorf(
-				"team role data (%T) could not be cast to required struct (%T)",
-				inputTeamRole[0].Value,
-				ubiDetailedStatsJSON{},
-			)
-			return
+		seasonalStats := make([]DetailedStats, 0, len(inputTeamRole))
+		for _, inputData := range inputTeamRole {
+			data, ok := inputData.Value.(*ubiDetailedStatsJSON)
+			if !ok {
+				err = fmt.Errorf(
+					"team role data (%T) could not be cast to required struct (%T)",
+					inputData.Value,
+					ubiDetailedStatsJSON{},
+				)
+				return
+			}
+			detailedStats := newDetailedStats(data)
+			seasonalStats = append(seasonalStats, *detailedStats)
+
+			if detailedStats.Season == "" {
+				continue
+			}
+			if stats.Seasons == nil {
+				stats.Seasons = map[string]SummarizedGameModeStats{}
+			}
+			seasonStats := stats.Seasons[detailedStats.Season]
+			seasonOutputTeamRole := []**DetailedStats{&seasonStats.All, &seasonStats.Attack, &seasonStats.Defence}[i]
+			*seasonOutputTeamRole = detailedStats
+			if seasonStats.matchStats.MatchesPlayed == 0 {
+				seasonStats.matchStats = detailedStats.matchStats
+			}
+			stats.Seasons[detailedStats.Season] = seasonStats
 		}
-		*outputTeamRoles[i] = newDetailedStats(data)
+		totalStats := combine(seasonalStats)
+		*outputTeamRoles[i] = &totalStats
 
 		if stats.matchStats.MatchesPlayed == 0 {
-			stats.matchStats = newMatchStats(data)
+			stats.matchStats = totalStats.matchStats
 		}
 	}
 	return
@@ -151,10 +230,13 @@ type MapStats struct {
 	statsLoader[map[string]NamedMapStatDetails, ubiTeamRolesJSON]
 }
 
+// NamedMapStatDetails contains the stats for a single map.
+// The embedded DetailedStats cover both team roles, Attack and Defence are nil if the corresponding team role was not requested or not played.
 type NamedMapStatDetails struct {
-	matchStats
 	DetailedStats
-	Bombsites *BombsiteGamemodeStats
+	Attack    *DetailedStats         `json:"attack,omitempty"`
+	Defence   *DetailedStats         `json:"defence,omitempty"`
+	Bombsites *BombsiteGamemodeStats `json:"bombsites,omitempty"`
 }
 
 func (s *MapStats) AggregationType() string {
@@ -170,25 +252,37 @@ func (s *MapStats) UnmarshalJSON(data []byte) (err error) {
 }
 
 func (s *MapStats) loadTeamRole(jsn *ubiTeamRolesJSON, stats *map[string]NamedMapStatDetails) (err error) {
-	inputTeamRole := jsn.TeamRoles.All
+	inputTeamRoles := [][]ubiTypedTeamRoleJSON{jsn.TeamRoles.All, jsn.TeamRoles.Attack, jsn.TeamRoles.Defence}
 
-	if len(inputTeamRole) == 0 {
-		return errors.New("no input data for team role 'ALL'")
+	if len(jsn.TeamRoles.All) == 0 && len(jsn.TeamRoles.Attack) == 0 && len(jsn.TeamRoles.Defence) == 0 {
+		return errors.New("no input data for any team role")
 	}
 	mapStats := map[string]NamedMapStatDetails{}
-	for _, mapData := range inputTeamRole {
-		data, ok := mapData.Value.(*ubiDetailedStatsJSON)
-		if !ok {
-			err = fmt.Errorf(
-				"team role data (%T) could not be cast to required struct (%T)",
-				mapData.Value,
-				ubiDetailedStatsJSON{},
-			)
-			return
-		}
-		mapStats[*data.StatsDetail] = NamedMapStatDetails{
-			DetailedStats: *newDetailedStats(data),
-			matchStats:    newMatchStats(data),
+	for i, inputTeamRole := range inputTeamRoles {
+		for _, mapData := range inputTeamRole {
+			data, ok := mapData.Value.(*ubiDetailedStatsJSON)
+			if !ok {
+				err = fmt.Errorf(
+					"team role data (%T) could not be cast to required struct (%T)",
+					mapData.Value,
+					ubiDetailedStatsJSON{},
+				)
+				return
+			}
+			mapName := statsDetailName(data)
+			mapDetails := mapStats[mapName]
+			detailedStats := newDetailedStats(data)
+			switch i {
+			case 0:
+				mapDetails.DetailedStats = *detailedStats
+			case 1:
+				mapDetails.Attack = detailedStats
+			case 2:
+				mapDetails.Defence = detailedStats
+			default:
+				return fmt.Errorf("unexpected team role index %d", i)
+			}
+			mapStats[mapName] = mapDetails
 		}
 	}
 	*stats = mapStats
@@ -206,14 +300,14 @@ type BombsiteStats struct {
 }
 
 type BombsiteGamemodeStats struct {
-	All     []BombsiteTeamRoleStats
-	Attack  []BombsiteTeamRoleStats
-	Defence []BombsiteTeamRoleStats
+	All     []BombsiteTeamRoleStats `json:"all,omitempty"`
+	Attack  []BombsiteTeamRoleStats `json:"attack,omitempty"`
+	Defence []BombsiteTeamRoleStats `json:"defence,omitempty"`
 }
 
 type BombsiteTeamRoleStats struct {
 	DetailedStats
-	Name string
+	Name string `json:"name"`
 }
 
 func (s *BombsiteStats) AggregationType() string {
@@ -250,7 +344,7 @@ func (s *BombsiteStats) loadTeamRole(jsn *ubiTeamRolesJSON, stats *BombsiteGamem
 			}
 			outputTeamRoleData[j] = BombsiteTeamRoleStats{
 				DetailedStats: *newDetailedStats(data),
-				Name:          *data.StatsDetail,
+				Name:          statsDetailName(data),
 			}
 		}
 
@@ -264,19 +358,22 @@ Weapons structs
 ***************/
 
 // WeaponStats provides stats aggregated by weapon type and name.
+// The current view is requested by default, the seasonal view can be requested with r6api.WithView or StatsQuery.View.
+// If a response contains multiple entries of the same weapon (e.g. for multiple seasons), they are combined with AggregateWeapons,
+// the entries of the individual seasons are kept in WeaponNamedStats.Seasons.
 type WeaponStats struct {
 	statsLoader[WeaponTeamRoles, ubiGameModeWeaponsJSON]
 }
 
 type WeaponTeamRoles struct {
-	All     *WeaponTypes
-	Attack  *WeaponTypes
-	Defence *WeaponTypes
+	All     *WeaponTypes `json:"all,omitempty"`
+	Attack  *WeaponTypes `json:"attack,omitempty"`
+	Defence *WeaponTypes `json:"defence,omitempty"`
 }
 
 type WeaponTypes struct {
-	PrimaryWeapons   WeaponTypesMap
-	SecondaryWeapons WeaponTypesMap
+	PrimaryWeapons   WeaponTypesMap `json:"primaryWeapons,omitempty"`
+	SecondaryWeapons WeaponTypesMap `json:"secondaryWeapons,omitempty"`
 }
 
 type WeaponTypesMap map[string]WeaponNamesMap
@@ -285,9 +382,13 @@ type WeaponNamesMap map[string]WeaponNamedStats
 
 type WeaponNamedStats struct {
 	reducedStats
-	RoundsWithKill      float64
-	RoundsWithMultikill float64
-	HeadshotPercentage  float64
+	Season              string  `json:"season,omitempty"` // only set for the seasonal view
+	RoundsWithKill      float64 `json:"roundsWithKill"`
+	RoundsWithMultikill float64 `json:"roundsWithMultikill"`
+	HeadshotPercentage  float64 `json:"headshotPercentage"`
+	// Seasons contains the stats of the weapon per season, keyed by season slug (e.g. "Y8S2").
+	// It is only populated if the response contains season information, entries do not contain Seasons themselves.
+	Seasons map[string]WeaponNamedStats `json:"seasons,omitempty"`
 }
 
 func (s *WeaponStats) AggregationType() string {
@@ -295,7 +396,7 @@ func (s *WeaponStats) AggregationType() string {
 }
 
 func (s *WeaponStats) ViewType() string {
-	return "current" // does not seem to support seasonal view
+	return "current"
 }
 
 func (s *WeaponStats) UnmarshalJSON(data []byte) error {
@@ -303,7 +404,7 @@ func (s *WeaponStats) UnmarshalJSON(data []byte) error {
 }
 
 func (s *WeaponStats) loadTeamRole(jsn *ubiGameModeWeaponsJSON, stats *WeaponTeamRoles) (err error) {
-	inputTeamRoles := []*ubiWeaponSlotsJSON{jsn.TeamRoles.All, jsn.TeamRoles.Attack, jsn.TeamRoles.Defence}
+	_, inputTeamRoles := jsn.byTeamRole()
 	outputTeamRoles := []**WeaponTypes{&stats.All, &stats.Attack, &stats.Defence}
 
 	for i, inputTeamRole := range inputTeamRoles {
@@ -328,65 +429,103 @@ func newWeaponTypesMap(v *ubiWeaponTypesJSON) WeaponTypesMap {
 	result := WeaponTypesMap{}
 	for _, weaponType := range v.WeaponTypes {
 		weaponTypeStats := make(WeaponNamesMap, len(weaponType.Weapons))
-		for _, weaponStats := range weaponType.Weapons {
-			weaponTypeStats[weaponStats.WeaponName] = WeaponNamedStats{
-				reducedStats: reducedStats{
-					Headshots:    weaponStats.Headshots,
-					Kills:        weaponStats.Kills,
-					RoundsPlayed: weaponStats.RoundsPlayed,
-					RoundsWon:    weaponStats.RoundsWon,
-					RoundsLost:   weaponStats.RoundsLost,
-				},
-				RoundsWithKill:      weaponStats.RoundsWithKill,
-				RoundsWithMultikill: weaponStats.RoundsWithMultikill,
-				HeadshotPercentage:  weaponStats.HeadshotPercentage,
+		for i := range weaponType.Weapons {
+			weaponStats := &weaponType.Weapons[i]
+			namedStats := newWeaponNamedStats(weaponStats)
+			if namedStats.Season != "" {
+				namedStats.Seasons = map[string]WeaponNamedStats{namedStats.Season: namedStats}
+			}
+			if existing, ok := weaponTypeStats[weaponStats.WeaponName]; ok {
+				namedStats = AggregateWeapons(existing, namedStats)
 			}
+			weaponTypeStats[weaponStats.WeaponName] = namedStats
 		}
 		result[weaponType.WeaponTypeName] = weaponTypeStats
 	}
 	return result
 }
 
+func newWeaponNamedStats(data *ubiWeaponStatsJSON) WeaponNamedStats {
+	return WeaponNamedStats{
+		Season: data.ubiSeasonInfo.slug(),
+		reducedStats: reducedStats{
+			Headshots:    data.Headshots,
+			Kills:        data.Kills,
+			RoundsPlayed: data.RoundsPlayed,
+			RoundsWon:    data.RoundsWon,
+			RoundsLost:   data.RoundsLost,
+		},
+		RoundsWithKill:      data.RoundsWithKill,
+		RoundsWithMultikill: data.RoundsWithMultikill,
+		HeadshotPercentage:  data.HeadshotPercentage,
+	}
+}
+
 /***************************
 Moving Point Average (Trend)
 ***************************/
 
 // MovingTrendStats provides stats without any specific aggregation, but with trends across a specific timeframe.
+// The positions of the points are interpreted according to DecodeOptions.TrendType, see TrendType.
 type MovingTrendStats struct {
 	statsLoader[MovingTrendTeamRoles, ubiTeamRolesJSON]
 }
 
 type MovingTrendTeamRoles struct {
-	All     *MovingTrend
-	Attack  *MovingTrend
-	Defence *MovingTrend
+	All     *MovingTrend `json:"all,omitempty"`
+	Attack  *MovingTrend `json:"attack,omitempty"`
+	Defence *MovingTrend `json:"defence,omitempty"`
 }
 
 type MovingTrend struct {
-	MovingPoints           int
-	DistancePerRound       MovingTrendEntry
-	HeadshotPercentage     MovingTrendEntry
-	KillDeathRatio         MovingTrendEntry
-	KillsPerRound          MovingTrendEntry
-	RatioTimeAlivePerMatch MovingTrendEntry
-	RoundsSurvived         MovingTrendEntry
-	RoundsWithKill         MovingTrendEntry
-	RoundsWithKOST         MovingTrendEntry
-	RoundsWithMultikill    MovingTrendEntry
-	RoundsWithOpeningDeath MovingTrendEntry
-	RoundsWithOpeningKill  MovingTrendEntry
-	WinLossRatio           MovingTrendEntry
+	MovingPoints           int              `json:"movingPoints"`
+	DistancePerRound       MovingTrendEntry `json:"distancePerRound"`
+	HeadshotPercentage     MovingTrendEntry `json:"headshotPercentage"`
+	KillDeathRatio         MovingTrendEntry `json:"killDeathRatio"`
+	KillsPerRound          MovingTrendEntry `json:"killsPerRound"`
+	RatioTimeAlivePerMatch MovingTrendEntry `json:"ratioTimeAlivePerMatch"`
+	RoundsSurvived         MovingTrendEntry `json:"roundsSurvived"`
+	RoundsWithKill         MovingTrendEntry `json:"roundsWithKill"`
+	RoundsWithKOST         MovingTrendEntry `json:"roundsWithKOST"`
+	RoundsWithMultikill    MovingTrendEntry `json:"roundsWithMultikill"`
+	RoundsWithOpeningDeath MovingTrendEntry `json:"roundsWithOpeningDeath"`
+	RoundsWithOpeningKill  MovingTrendEntry `json:"roundsWithOpeningKill"`
+	WinLossRatio           MovingTrendEntry `json:"winLossRatio"`
 }
 
 type MovingTrendEntry struct {
-	Low     float64
-	Average float64
-	High    float64
-	Actuals MovingTrendPoints
-	Trend   MovingTrendPoints
+	// MovingPoints is the number of points the trend is averaged over.
+	// It is the same for all entries of a MovingTrend (see MovingTrend.MovingPoints) and repeated here
+	// so that a single series can be charted or serialized without its MovingTrend.
+	MovingPoints int               `json:"movingPoints"`
+	Low          float64           `json:"low"`
+	Average      float64           `json:"average"`
+	High         float64           `json:"high"`
+	Actuals      MovingTrendPoints `json:"actuals"`
+	Trend        MovingTrendPoints `json:"trend"`
+}
+
+// MovingTrendPoint is a single point of a moving trend series.
+type MovingTrendPoint struct {
+	Position int        `json:"position"`       // position on the x-axis as returned by the API, usually the match number starting at 1
+	Date     *time.Time `json:"date,omitempty"` // date of the point if the trend was requested with TrendByDay (Position is the date as YYYYMMDD then)
+	Value    float64    `json:"value"`
+}
+
+// MovingTrendPoints are the points of a moving trend series, ordered by position.
+// Positions missing in the response are not filled in, i.e. positions can have gaps.
+type MovingTrendPoints []MovingTrendPoint
+
+// Values returns the values of the points in order, ignoring their positions.
+func (p MovingTrendPoints) Values() []float64 {
+	values := make([]float64, len(p))
+	for i, point := range p {
+		values[i] = point.Value
+	}
+	return values
 }
 
-type MovingTrendPoints []float64
+const movingTrendDateLayout = "20060102"
 
 func (s *MovingTrendStats) AggregationType() string {
 	return "movingpoint"
@@ -400,7 +539,7 @@ func (s *MovingTrendStats) UnmarshalJSON(data []byte) error {
 	return s.loadRawStats(data, s, s.loadTeamRole)
 }
 
-func (*MovingTrendStats) loadTeamRole(jsn *ubiTeamRolesJSON, stats *MovingTrendTeamRoles) (err error) {
+func (s *MovingTrendStats) loadTeamRole(jsn *ubiTeamRolesJSON, stats *MovingTrendTeamRoles) (err error) {
 	inputTeamRoles := [][]ubiTypedTeamRoleJSON{jsn.TeamRoles.All, jsn.TeamRoles.Attack, jsn.TeamRoles.Defence}
 	outputTeamRoles := []**MovingTrend{&stats.All, &stats.Attack, &stats.Defence}
 
@@ -417,46 +556,78 @@ func (*MovingTrendStats) loadTeamRole(jsn *ubiTeamRolesJSON, stats *MovingTrendT
 			)
 			return
 		}
-		*outputTeamRoles[i] = newMovingTrendStats(data)
+		if *outputTeamRoles[i], err = newMovingTrendStats(data, s.decodeOptions.TrendType); err != nil {
+			return
+		}
 	}
 	return
 }
 
-func newMovingTrendStats(v *ubiMovingTrendJSON) *MovingTrend {
-	return &MovingTrend{
+func newMovingTrendStats(v *ubiMovingTrendJSON, trendType TrendType) (trend *MovingTrend, err error) {
+	entry := func(e ubiMovingTrendEntryJSON) MovingTrendEntry {
+		if err != nil {
+			return MovingTrendEntry{}
+		}
+		var result MovingTrendEntry
+		result, err = newMovingTrendEntry(e, v.MovingPoints, trendType)
+		return result
+	}
+	trend = &MovingTrend{
 		MovingPoints:           v.MovingPoints,
-		DistancePerRound:       newMovingTrendEntry(v.DistancePerRound),
-		HeadshotPercentage:     newMovingTrendEntry(v.HeadshotPercentage),
-		KillDeathRatio:         newMovingTrendEntry(v.KillDeathRatio),
-		KillsPerRound:          newMovingTrendEntry(v.KillsPerRound),
-		RatioTimeAlivePerMatch: newMovingTrendEntry(v.RatioTimeAlivePerMatch),
-		RoundsSurvived:         newMovingTrendEntry(v.RoundsSurvived),
-		RoundsWithKill:         newMovingTrendEntry(v.RoundsWithKill),
-		RoundsWithKOST:         newMovingTrendEntry(v.RoundsWithKOST),
-		RoundsWithMultikill:    newMovingTrendEntry(v.RoundsWithMultikill),
-		RoundsWithOpeningDeath: newMovingTrendEntry(v.RoundsWithOpeningDeath),
-		RoundsWithOpeningKill:  newMovingTrendEntry(v.RoundsWithOpeningKill),
-		WinLossRatio:           newMovingTrendEntry(v.WinLossRatio),
+		DistancePerRound:       entry(v.DistancePerRound),
+		HeadshotPercentage:     entry(v.HeadshotPercentage),
+		KillDeathRatio:         entry(v.KillDeathRatio),
+		KillsPerRound:          entry(v.KillsPerRound),
+		RatioTimeAlivePerMatch: entry(v.RatioTimeAlivePerMatch),
+		RoundsSurvived:         entry(v.RoundsSurvived),
+		RoundsWithKill:         entry(v.RoundsWithKill),
+		RoundsWithKOST:         entry(v.RoundsWithKOST),
+		RoundsWithMultikill:    entry(v.RoundsWithMultikill),
+		RoundsWithOpeningDeath: entry(v.RoundsWithOpeningDeath),
+		RoundsWithOpeningKill:  entry(v.RoundsWithOpeningKill),
+		WinLossRatio:           entry(v.WinLossRatio),
 	}
+	if err != nil {
+		return nil, err
+	}
+	return trend, nil
 }
 
-func newMovingTrendEntry(v ubiMovingTrendEntryJSON) MovingTrendEntry {
-	result := MovingTrendEntry{
-		Low:     v.Low,
-		Average: v.Average,
-		High:    v.High,
+func newMovingTrendEntry(v ubiMovingTrendEntryJSON, movingPoints int, trendType TrendType) (entry MovingTrendEntry, err error) {
+	entry = MovingTrendEntry{
+		MovingPoints: movingPoints,
+		Low:          v.Low,
+		Average:      v.Average,
+		High:         v.High,
+	}
+	if entry.Actuals, err = newMovingTrendPoints(v.Actuals, trendType); err != nil {
+		return
 	}
-	inputFields := []ubiMovingTrendPoints{v.Actuals, v.Trend}
-	outputFields := []*MovingTrendPoints{&result.Actuals, &result.Trend}
+	entry.Trend, err = newMovingTrendPoints(v.Trend, trendType)
+	return
+}
 
-	for i, inputField := range inputFields {
-		points := make(MovingTrendPoints, len(inputField))
-		for j, v := range inputField {
-			points[j-1] = v // -1 since index in JSON starts at 1
+// newMovingTrendPoints converts the points to a list ordered by position.
+// Positions are not required to be contiguous or to start at a specific index.
+// For TrendByDay, all positions need to be dates.
+func newMovingTrendPoints(v ubiMovingTrendPoints, trendType TrendType) (MovingTrendPoints, error) {
+	points := make(MovingTrendPoints, 0, len(v))
+	for key, value := range v {
+		position, _ := strconv.Atoi(key) // validated when decoding
+		point := MovingTrendPoint{Position: position, Value: value}
+		if trendType == TrendByDay {
+			date, err := time.Parse(movingTrendDateLayout, key)
+			if err != nil {
+				return nil, fmt.Errorf("moving trend position '%s' is not a date: %w", key, err)
+			}
+			point.Date = &date
 		}
-		*outputFields[i] = points
+		points = append(points, point)
 	}
-	return result
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Position < points[j].Position
+	})
+	return points, nil
 }
 
 /**************
@@ -464,46 +635,53 @@ Generic structs
 ***************/
 
 type reducedStats struct {
-	Headshots    int
-	Kills        int
-	RoundsPlayed int
-	RoundsWon    int
-	RoundsLost   int
+	Headshots    int `json:"headshots"`
+	Kills        int `json:"kills"`
+	RoundsPlayed int `json:"roundsPlayed"`
+	RoundsWon    int `json:"roundsWon"`
+	RoundsLost   int `json:"roundsLost"`
 }
 
+// matchStats contains match counts.
 type matchStats struct {
-	MatchesPlayed int
-	MatchesWon    int
-	MatchesLost   int
+	// MatchesPlayed is the number of matches played.
+	// In stats combined across operators (e.g. the "All" row of operator stats or stats combined with Aggregate),
+	// matches are counted once per operator played in them, so this can exceed the number of matches actually played.
+	// The same applies to MatchesWon and MatchesLost.
+	MatchesPlayed int `json:"matchesPlayed"`
+	MatchesWon    int `json:"matchesWon"`
+	MatchesLost   int `json:"matchesLost"`
 }
 
 type DetailedStats struct {
 	reducedStats
-	MinutesPlayed        int
-	Assists              int
-	Deaths               int
-	KillsPerRound        float64
-	MeleeKills           int
-	TeamKills            int
-	HeadshotPercentage   float64
-	EntryDeaths          int
-	EntryDeathTrades     int
-	EntryKills           int
-	EntryKillTrades      int
-	Trades               int
-	Revives              int
-	RoundsSurvived       float64
-	RoundsWithKill       float64
-	RoundsWithMultikill  float64
-	RoundsWithAce        float64
-	RoundsWithClutch     float64
-	RoundsWithKOST       float64
-	RoundsWithEntryDeath float64
-	RoundsWithEntryKill  float64
-	DistancePerRound     float64
-	DistanceTotal        float64
-	TimeAlivePerMatch    float64
-	TimeDeadPerMatch     float64
+	matchStats
+	Season               string  `json:"season,omitempty"` // season slug (e.g. "Y8S2"), empty if unknown or aggregated across seasons
+	MinutesPlayed        int     `json:"minutesPlayed"`
+	Assists              int     `json:"assists"`
+	Deaths               int     `json:"deaths"`
+	KillsPerRound        float64 `json:"killsPerRound"`
+	MeleeKills           int     `json:"meleeKills"`
+	TeamKills            int     `json:"teamKills"`
+	HeadshotPercentage   float64 `json:"headshotPercentage"`
+	EntryDeaths          int     `json:"entryDeaths"`
+	EntryDeathTrades     int     `json:"entryDeathTrades"`
+	EntryKills           int     `json:"entryKills"`
+	EntryKillTrades      int     `json:"entryKillTrades"`
+	Trades               int     `json:"trades"`
+	Revives              int     `json:"revives"`
+	RoundsSurvived       float64 `json:"roundsSurvived"`
+	RoundsWithKill       float64 `json:"roundsWithKill"`
+	RoundsWithMultikill  float64 `json:"roundsWithMultikill"`
+	RoundsWithAce        float64 `json:"roundsWithAce"`
+	RoundsWithClutch     float64 `json:"roundsWithClutch"`
+	RoundsWithKOST       float64 `json:"roundsWithKOST"`
+	RoundsWithEntryDeath float64 `json:"roundsWithEntryDeath"`
+	RoundsWithEntryKill  float64 `json:"roundsWithEntryKill"`
+	DistancePerRound     float64 `json:"distancePerRound"`
+	DistanceTotal        float64 `json:"distanceTotal"`
+	TimeAlivePerMatch    float64 `json:"timeAlivePerMatch"`
+	TimeDeadPerMatch     float64 `json:"timeDeadPerMatch"`
 }
 
 func newDetailedStats(data *ubiDetailedStatsJSON) *DetailedStats {
@@ -515,6 +693,8 @@ func newDetailedStats(data *ubiDetailedStatsJSON) *DetailedStats {
 			RoundsWon:    data.RoundsWon,
 			RoundsLost:   data.RoundsLost,
 		},
+		matchStats:           newMatchStats(data),
+		Season:               data.ubiSeasonInfo.slug(),
 		MinutesPlayed:        data.MinutesPlayed,
 		Assists:              data.Assists,
 		Deaths:               data.Deaths,
@@ -543,98 +723,12 @@ func newDetailedStats(data *ubiDetailedStatsJSON) *DetailedStats {
 	}
 }
 
-func newTotalDetailedTeamRoleStats(data []ubiTypedTeamRoleJSON) (*DetailedStats, error) {
-	v := &DetailedStats{
-		reducedStats: reducedStats{
-			Headshots:    0,
-			Kills:        0,
-			RoundsPlayed: 0,
-			RoundsWon:    0,
-			RoundsLost:   0,
-		},
-		MinutesPlayed:        0,
-		Assists:              0,
-		Deaths:               0,
-		KillsPerRound:        0,
-		MeleeKills:           0,
-		TeamKills:            0,
-		HeadshotPercentage:   0,
-		EntryDeaths:          0,
-		EntryDeathTrades:     0,
-		EntryKills:           0,
-		EntryKillTrades:      0,
-		Trades:               0,
-		Revives:              0,
-		RoundsSurvived:       0,
-		RoundsWithKill:       0,
-		RoundsWithMultikill:  0,
-		RoundsWithAce:        0,
-		RoundsWithClutch:     0,
-		RoundsWithKOST:       0,
-		RoundsWithEntryDeath: 0,
-		RoundsWithEntryKill:  0,
-		DistancePerRound:     0,
-		DistanceTotal:        0,
-		TimeAlivePerMatch:    0,
-		TimeDeadPerMatch:     0,
-	}
-
-	count := float64(len(data))
-	for _, entry := range data {
-		casted, ok := entry.Value.(*ubiDetailedStatsJSON)
-		if !ok {
-			return nil, fmt.Errorf(
-				"team role data (%T) could not be cast to required struct (%T)",
-				entry.Value,
-				ubiDetailedStatsJSON{},
-			)
-		}
-		v.Headshots += casted.Headshots
-		v.Kills += casted.Kills
-		v.RoundsPlayed += casted.RoundsPlayed
-		v.RoundsWon += casted.RoundsWon
-		v.RoundsLost += casted.RoundsLost
-		v.MinutesPlayed += casted.MinutesPlayed
-		v.Assists += casted.Assists
-		v.Deaths += casted.Deaths
-		v.KillsPerRound += casted.KillsPerRound.Value
-		v.MeleeKills += casted.MeleeKills
-		v.TeamKills += casted.TeamKills
-		v.HeadshotPercentage += casted.HeadshotPercentage.Value
-		v.EntryDeaths += casted.EntryDeaths
-		v.EntryDeathTrades += casted.EntryDeathTrades
-		v.EntryKills += casted.EntryKills
-		v.EntryKillTrades += casted.EntryKillTrades
-		v.Trades += casted.Trades
-		v.Revives += casted.Revives
-		v.RoundsSurvived += casted.RoundsSurvived.Value
-		v.RoundsWithKill += casted.RoundsWithKill.Value
-		v.RoundsWithMultikill += casted.RoundsWithKill.Value
-		v.RoundsWithAce += casted.RoundsWithAce.Value
-		v.RoundsWithClutch += casted.RoundsWithClutch.Value
-		v.RoundsWithKOST += casted.RoundsWithKOST.Value
-		v.RoundsWithEntryDeath += casted.RoundsWithEntryDeath.Value
-		v.RoundsWithEntryKill += casted.RoundsWithEntryKill.Value
-		v.DistancePerRound += casted.DistancePerRound
-		v.DistanceTotal += casted.DistanceTotal
-		v.TimeAlivePerMatch += casted.TimeAlivePerMatch
-		v.TimeDeadPerMatch += casted.TimeDeadPerMatch
+// statsDetailName returns the name of the entry in data, or "n/a" for entries without name.
+func statsDetailName(data *ubiDetailedStatsJSON) string {
+	if data.StatsDetail == nil {
+		return "n/a"
 	}
-
-	v.KillsPerRound /= count
-	v.HeadshotPercentage /= count
-	v.RoundsSurvived /= count
-	v.RoundsWithKill /= count
-	v.RoundsWithMultikill /= count
-	v.RoundsWithAce /= count
-	v.RoundsWithClutch /= count
-	v.RoundsWithKOST /= count
-	v.RoundsWithEntryDeath /= count
-	v.RoundsWithEntryKill /= count
-	v.DistancePerRound /= count
-	v.TimeAlivePerMatch /= count
-	v.TimeDeadPerMatch /= count
-	return v, nil
+	return *data.StatsDetail
 }
 
 type NamedStats struct {
@@ -644,9 +738,12 @@ type NamedStats struct {
 type NamedTeamRoleStats map[string]DetailedStats
 
 type NamedTeamRoles struct {
-	All     NamedTeamRoleStats
-	Attack  NamedTeamRoleStats
-	Defence NamedTeamRoleStats
+	All     NamedTeamRoleStats `json:"all,omitempty"`
+	Attack  NamedTeamRoleStats `json:"attack,omitempty"`
+	Defence NamedTeamRoleStats `json:"defence,omitempty"`
+	// Seasons contains the same stats per season, keyed by season slug (e.g. "Y8S2").
+	// It is only populated if the response contains season information, entries do not contain Seasons themselves.
+	Seasons map[string]NamedTeamRoles `json:"seasons,omitempty"`
 }
 
 func (s *NamedStats) loadTeamRole(jsn *ubiTeamRolesJSON, stats *NamedTeamRoles) (err error) {
@@ -657,41 +754,68 @@ func (s *NamedStats) loadTeamRole(jsn *ubiTeamRolesJSON, stats *NamedTeamRoles)
 		if len(teamRoleData) == 0 {
 			continue
 		}
-		resultTeamRoleData := make(NamedTeamRoleStats, len(teamRoleData)+1)
-
-		// calculate total
-		var totalData *DetailedStats
-		totalData, err = newTotalDetailedTeamRoleStats(teamRoleData)
-		if err != nil {
-			return
-		}
-		resultTeamRoleData["All"] = *totalData
+		// a name can occur multiple times if the response contains multiple seasons
+		namedStats := make(map[string][]DetailedStats, len(teamRoleData))
 
-		// calculate named
 		for _, teamRoleStats := range teamRoleData {
 			data, ok := teamRoleStats.Value.(*ubiDetailedStatsJSON)
 			if !ok {
 				err = fmt.Errorf(
 					"team role data (%T) could not be cast to required struct (%T)",
-					teamRoleData[0].Value,
+					teamRoleStats.Value,
 					ubiDetailedStatsJSON{},
 				)
 				return
 			}
-			var name string
-			if data.StatsDetail == nil {
-				name = "n/a"
-			} else {
-				name = *data.StatsDetail
+			name := statsDetailName(data)
+			detailedStats := *newDetailedStats(data)
+			namedStats[name] = append(namedStats[name], detailedStats)
+
+			if detailedStats.Season == "" {
+				continue
+			}
+			if stats.Seasons == nil {
+				stats.Seasons = map[string]NamedTeamRoles{}
 			}
-			resultTeamRoleData[name] = *newDetailedStats(data)
+			seasonStats := stats.Seasons[detailedStats.Season]
+			seasonResultField := []*NamedTeamRoleStats{&seasonStats.All, &seasonStats.Attack, &seasonStats.Defence}[i]
+			if *seasonResultField == nil {
+				*seasonResultField = NamedTeamRoleStats{}
+			}
+			(*seasonResultField)[name] = detailedStats
+			stats.Seasons[detailedStats.Season] = seasonStats
 		}
+
+		resultTeamRoleData := make(NamedTeamRoleStats, len(namedStats)+1)
+		for name, v := range namedStats {
+			resultTeamRoleData[name] = combine(v)
+		}
+		resultTeamRoleData.addTotal()
 		*resultFields[i] = resultTeamRoleData
 	}
 
+	for _, seasonStats := range stats.Seasons {
+		for _, seasonTeamRoleData := range []NamedTeamRoleStats{seasonStats.All, seasonStats.Attack, seasonStats.Defence} {
+			if seasonTeamRoleData != nil {
+				seasonTeamRoleData.addTotal()
+			}
+		}
+	}
+
 	return
 }
 
+// addTotal adds an entry "All" which aggregates all other entries.
+func (s NamedTeamRoleStats) addTotal() {
+	namedStats := make([]DetailedStats, 0, len(s))
+	for name, v := range s {
+		if name != "All" {
+			namedStats = append(namedStats, v)
+		}
+	}
+	s["All"] = Aggregate(namedStats...)
+}
+
 func newMatchStats(jsn *ubiDetailedStatsJSON) matchStats {
 	return matchStats{
 		MatchesPlayed: jsn.MatchesPlayed,
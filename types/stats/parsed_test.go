@@ -0,0 +1,160 @@
+package stats
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMovingTrendPoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []int
+		wantErr bool
+	}{
+		{name: "contiguous", data: `{"1":0.1,"2":0.2,"3":0.3}`, want: []int{1, 2, 3}},
+		{name: "sparse", data: `{"7":0.7,"2":0.2,"4":0.4}`, want: []int{2, 4, 7}},
+		{name: "zero-based", data: `{"0":0.0,"1":0.1}`, want: []int{0, 1}},
+		{name: "dates", data: `{"20230704":0.2,"20230703":0.1}`, want: []int{20230703, 20230704}},
+		{name: "invalid position", data: `{"first":0.1}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jsn ubiMovingTrendPoints
+			err := json.Unmarshal([]byte(tt.data), &jsn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			points, err := newMovingTrendPoints(jsn, TrendByMatch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(points) != len(tt.want) {
+				t.Fatalf("want %d points, got %d", len(tt.want), len(points))
+			}
+			for i, point := range points {
+				if point.Position != tt.want[i] {
+					t.Errorf("point %d: want position %d, got %d", i, tt.want[i], point.Position)
+				}
+			}
+		})
+	}
+}
+
+func TestMovingTrendPointsTrendType(t *testing.T) {
+	var jsn ubiMovingTrendPoints
+	if err := json.Unmarshal([]byte(`{"20230704":0.2,"20230703":0.1}`), &jsn); err != nil {
+		t.Fatal(err)
+	}
+
+	// dates are only assigned if the trend was requested by day, regardless of how the positions look
+	points, err := newMovingTrendPoints(jsn, TrendByMatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if points[0].Date != nil {
+		t.Error("points of trends by match should not have a date")
+	}
+
+	if points, err = newMovingTrendPoints(jsn, TrendByDay); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC); points[0].Date == nil || !points[0].Date.Equal(want) {
+		t.Errorf("want date %s, got %v", want, points[0].Date)
+	}
+
+	if err = json.Unmarshal([]byte(`{"1":0.1,"2":0.2}`), &jsn); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = newMovingTrendPoints(jsn, TrendByDay); err == nil {
+		t.Error("want error for trend by day positioned by match")
+	}
+}
+
+func TestWeaponStatsSeasonal(t *testing.T) {
+	weapon := func(season string, kills int) string {
+		return `{"weaponName":"R4-C","seasonYear":"Y8","seasonNumber":"` + season + `","headshots":0,"kills":` + strconv.Itoa(kills) +
+			`,"roundsPlayed":10,"roundsWon":5,"roundsLost":5,"roundsWithAKill":0.5,"roundsWithMultikill":0.1,"headshotAccuracy":0}`
+	}
+	data := `{"userId":"id","profileData":{"id":{"platforms":{"PC":{"gameModes":{"ranked":{"type":"Team roles weapons","teamRoles":{"all":{"weaponSlots":{"primaryWeapons":{"weaponTypes":[{"weaponType":"Assault Rifle","weapons":[` +
+		weapon("S1", 4) + `,` + weapon("S2", 6) + `]}]}}}}}}}}}}}`
+
+	s := new(WeaponStats)
+	if err := s.UnmarshalJSON([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	got := s.Ranked.All.PrimaryWeapons["Assault Rifle"]["R4-C"]
+	if got.Kills != 10 || got.RoundsPlayed != 20 || got.Season != "" {
+		t.Errorf("want entries of both seasons to be combined, got %+v", got)
+	}
+	if len(got.Seasons) != 2 || got.Seasons["Y8S1"].Kills != 4 || got.Seasons["Y8S2"].Kills != 6 || got.Seasons["Y8S2"].Season != "Y8S2" {
+		t.Errorf("want entries of both seasons to be kept, got %+v", got.Seasons)
+	}
+	if got.Seasons["Y8S1"].Seasons != nil {
+		t.Error("season entries should not contain seasons themselves")
+	}
+
+	data = `{"userId":"id","profileData":{"id":{"platforms":{"PC":{"gameModes":{"ranked":{"type":"Team roles weapons","teamRoles":{"all":{"weaponSlots":{"primaryWeapons":{"weaponTypes":[{"weaponType":"Assault Rifle","weapons":[` +
+		weapon("S2", 6) + `]}]}}}}}}}}}}}`
+	if err := s.UnmarshalJSON([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if got = s.Ranked.All.PrimaryWeapons["Assault Rifle"]["R4-C"]; got.Season != "Y8S2" {
+		t.Errorf("want season Y8S2, got '%s'", got.Season)
+	}
+}
+
+func TestMissingStatsDetail(t *testing.T) {
+	tests := []struct {
+		fixture string
+		name    string
+		dst     Provider
+		got     func(Provider) bool
+	}{
+		{
+			fixture: "maps.json",
+			name:    "CLUBHOUSE",
+			dst:     new(MapStats),
+			got: func(p Provider) bool {
+				_, ok := (*p.(*MapStats).All)["n/a"]
+				return ok
+			},
+		},
+		{
+			fixture: "bombsites.json",
+			name:    "2F Gym / 2F Bedroom",
+			dst:     new(BombsiteStats),
+			got: func(p Provider) bool {
+				for _, b := range p.(*BombsiteStats).All.All {
+					if b.Name == "n/a" {
+						return true
+					}
+				}
+				return false
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			data, err := os.ReadFile(sharedTestdata + tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data = []byte(strings.Replace(string(data), `"statsDetail": "`+tt.name+`",`, "", 1))
+			if err = json.Unmarshal(data, tt.dst); err != nil {
+				t.Fatal(err)
+			}
+			if !tt.got(tt.dst) {
+				t.Error(`want entry without statsDetail to be named "n/a"`)
+			}
+		})
+	}
+}
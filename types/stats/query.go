@@ -0,0 +1,285 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	ubiStatsURL     = "https://prod.datadev.ubisoft.com/v1/users/"
+	ubiStatsSpaceID = "5172a557-50b5-4665-b7db-e3f2e8c5041d"
+)
+
+type PlatformGroup string
+
+// PC is the only platform group currently supported when decoding responses.
+const PC PlatformGroup = "PC"
+
+// TrendType determines what the positions of moving trend points refer to, see MovingTrendPoint.
+type TrendType string
+
+const (
+	TrendByMatch TrendType = "matches" // positions are match numbers starting at 1, the default of the API
+	TrendByDay   TrendType = "days"    // positions are dates in the format YYYYMMDD
+)
+
+// StatsQuery describes a request to the datadev playerstats endpoint.
+// It is built by chaining its methods, starting with NewStatsQuery:
+//
+//	q := stats.NewStatsQuery().Seasons("Y8S1", "Y8S2").GameModes(stats.RANKED).TeamRoles(stats.ATTACKER).Maps("CLUBHOUSE")
+//
+// Game modes, team roles and platform group default to all game modes, all team roles and PC respectively,
+// all other filters are omitted from the request if not set.
+type StatsQuery struct {
+	aggregation   string
+	view          string
+	seasons       []string
+	gameModes     []GameMode
+	teamRoles     []TeamRole
+	platformGroup PlatformGroup
+	maps          []string
+	operators     []string
+	startDate     time.Time
+	endDate       time.Time
+	trendType     TrendType
+}
+
+// NewStatsQuery creates a new query without any filters.
+func NewStatsQuery() *StatsQuery {
+	return &StatsQuery{}
+}
+
+// Clone returns a copy of q which can be modified without affecting q.
+func (q *StatsQuery) Clone() *StatsQuery {
+	c := *q
+	c.seasons = append([]string(nil), q.seasons...)
+	c.gameModes = append([]GameMode(nil), q.gameModes...)
+	c.teamRoles = append([]TeamRole(nil), q.teamRoles...)
+	c.maps = append([]string(nil), q.maps...)
+	c.operators = append([]string(nil), q.operators...)
+	return &c
+}
+
+// ForProvider returns a copy of q requesting the aggregation of p, using the view of p unless it is set explicitly.
+func (q *StatsQuery) ForProvider(p Provider) *StatsQuery {
+	c := q.Clone()
+	c.aggregation = p.AggregationType()
+	if c.view == "" {
+		c.view = p.ViewType()
+	}
+	return c
+}
+
+// Aggregation sets the type of aggregation (e.g. "operators").
+func (q *StatsQuery) Aggregation(aggregation string) *StatsQuery {
+	q.aggregation = aggregation
+	return q
+}
+
+// View sets the type of view (e.g. "seasonal").
+func (q *StatsQuery) View(view string) *StatsQuery {
+	q.view = view
+	return q
+}
+
+// Seasons restricts the stats to the provided seasons (e.g. "Y8S2").
+func (q *StatsQuery) Seasons(seasons ...string) *StatsQuery {
+	q.seasons = seasons
+	return q
+}
+
+// GameModes restricts the stats to the provided game modes.
+func (q *StatsQuery) GameModes(gameModes ...GameMode) *StatsQuery {
+	q.gameModes = gameModes
+	return q
+}
+
+// TeamRoles restricts the stats to the provided team roles.
+func (q *StatsQuery) TeamRoles(teamRoles ...TeamRole) *StatsQuery {
+	q.teamRoles = teamRoles
+	return q
+}
+
+// PlatformGroup sets the platform group.
+// Only PC is supported since the providers of this package only decode stats of that platform group, URL returns an error for all others.
+func (q *StatsQuery) PlatformGroup(platformGroup PlatformGroup) *StatsQuery {
+	q.platformGroup = platformGroup
+	return q
+}
+
+// Maps restricts the stats to the provided maps (e.g. "CLUBHOUSE").
+func (q *StatsQuery) Maps(maps ...string) *StatsQuery {
+	q.maps = maps
+	return q
+}
+
+// Operators restricts the stats to the provided operators (e.g. "Ash").
+func (q *StatsQuery) Operators(operators ...string) *StatsQuery {
+	q.operators = operators
+	return q
+}
+
+// DateRange restricts the stats to matches played between start and end (both inclusive, only the date in their location is considered).
+// Date ranges are only supported for some aggregations and cannot be combined with seasons, see URL for all restrictions.
+func (q *StatsQuery) DateRange(start time.Time, end time.Time) *StatsQuery {
+	q.startDate = start
+	q.endDate = end
+	return q
+}
+
+// TrendType sets the trend type of moving trends, only applicable to the "movingpoint" aggregation.
+// The API positions points by match if not set.
+func (q *StatsQuery) TrendType(trendType TrendType) *StatsQuery {
+	q.trendType = trendType
+	return q
+}
+
+// HasDateRange returns true if a date range is set, see DateRange.
+func (q *StatsQuery) HasDateRange() bool {
+	return !q.startDate.IsZero() || !q.endDate.IsZero()
+}
+
+// DecodeOptions returns opts with all options depending on the query (i.e. the trend type) set accordingly.
+// It should be used to decode responses to this query, e.g. via Decodable.SetDecodeOptions.
+func (q *StatsQuery) DecodeOptions(opts DecodeOptions) DecodeOptions {
+	opts.TrendType = q.trendType
+	return opts
+}
+
+// URL returns the request URL of this query for the profile with profileID.
+// Aggregation and view need to be set, the platform group needs to be PC if set.
+// If a date range is set, it returns an error wrapping ErrInvalidDateRange if
+//   - only one of start and end date is set
+//   - the start date is after the end date
+//   - the end date is in the future
+//   - seasons are set as well
+//   - the aggregation does not support date ranges (only summary, operators, maps, bombsites and weapons do)
+//
+// The length of the range is not restricted since the window served by the API is undocumented.
+// Ranges exceeding it are rejected by the API itself, r6api.R6API.GetStats returns an error wrapping ErrInvalidDateRange in that case.
+func (q *StatsQuery) URL(profileID string) (string, error) {
+	if err := q.validate(time.Now()); err != nil {
+		return "", err
+	}
+
+	gameModes := q.gameModes
+	if len(gameModes) == 0 {
+		gameModes = []GameMode{ALL, RANKED, UNRANKED, CASUAL}
+	}
+	teamRoles := q.teamRoles
+	if len(teamRoles) == 0 {
+		teamRoles = []TeamRole{ALL_ROLES, ATTACKER, DEFENDER}
+	}
+	platformGroup := q.platformGroup
+	if platformGroup == "" {
+		platformGroup = PC
+	}
+
+	var b strings.Builder
+	b.WriteString(ubiStatsURL)
+	b.WriteString(url.PathEscape(profileID))
+	b.WriteString("/playerstats?spaceId=" + ubiStatsSpaceID)
+	b.WriteString("&view=" + url.QueryEscape(q.view))
+	b.WriteString("&aggregation=" + url.QueryEscape(q.aggregation))
+	b.WriteString("&gameMode=" + queryList(gameModes))
+	b.WriteString("&platformGroup=" + url.QueryEscape(string(platformGroup)))
+	b.WriteString("&teamRole=" + queryList(teamRoles))
+	if len(q.seasons) > 0 {
+		b.WriteString("&seasons=" + queryList(q.seasons))
+	}
+	if len(q.maps) > 0 {
+		b.WriteString("&maps=" + queryList(q.maps))
+	}
+	if len(q.operators) > 0 {
+		b.WriteString("&operators=" + queryList(q.operators))
+	}
+	if !q.startDate.IsZero() {
+		b.WriteString("&startDate=" + q.startDate.Format(queryDateLayout))
+	}
+	if !q.endDate.IsZero() {
+		b.WriteString("&endDate=" + q.endDate.Format(queryDateLayout))
+	}
+	if q.trendType != "" {
+		b.WriteString("&trendType=" + url.QueryEscape(string(q.trendType)))
+	}
+	return b.String(), nil
+}
+
+const queryDateLayout = "20060102"
+
+// ErrInvalidDateRange is returned by StatsQuery.URL if the date range cannot be served by the API.
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+var dateRangeAggregations = []string{"summary", "operators", "maps", "bombsites", "weapons"}
+
+// validate checks the query, considering end dates after the date of now to be in the future.
+func (q *StatsQuery) validate(now time.Time) error {
+	if q.aggregation == "" || q.view == "" {
+		return errors.New("aggregation and view of query need to be set")
+	}
+	if q.platformGroup != "" && q.platformGroup != PC {
+		return fmt.Errorf("unsupported platform group '%s', only %s is supported", q.platformGroup, PC)
+	}
+	if q.trendType != "" && q.aggregation != "movingpoint" {
+		return fmt.Errorf("trend type is not supported for aggregation '%s'", q.aggregation)
+	}
+	return q.validateDateRange(now)
+}
+
+func (q *StatsQuery) validateDateRange(now time.Time) error {
+	if q.startDate.IsZero() && q.endDate.IsZero() {
+		return nil
+	}
+	if q.startDate.IsZero() || q.endDate.IsZero() {
+		return fmt.Errorf("%w: both start and end date need to be set", ErrInvalidDateRange)
+	}
+	start, end := truncateToDate(q.startDate), truncateToDate(q.endDate)
+	if start.After(end) {
+		return fmt.Errorf("%w: start date %s is after end date %s", ErrInvalidDateRange, start.Format(time.DateOnly), end.Format(time.DateOnly))
+	}
+	if today := truncateToDate(now.In(q.endDate.Location())); end.After(today) {
+		return fmt.Errorf("%w: end date %s is in the future", ErrInvalidDateRange, end.Format(time.DateOnly))
+	}
+	if len(q.seasons) > 0 {
+		return fmt.Errorf("%w: date range cannot be combined with seasons", ErrInvalidDateRange)
+	}
+	if !contains(dateRangeAggregations, q.aggregation) {
+		return fmt.Errorf("%w: not supported for aggregation '%s'", ErrInvalidDateRange, q.aggregation)
+	}
+	return nil
+}
+
+// truncateToDate returns midnight of the date of t in UTC, which allows comparing dates across locations.
+func truncateToDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// PreviousWeek returns the Monday and Sunday of the week before the one containing t, e.g. for use with StatsQuery.DateRange.
+func PreviousWeek(t time.Time) (monday time.Time, sunday time.Time) {
+	year, month, day := t.Date()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	thisMonday := time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, t.Location())
+	return thisMonday.AddDate(0, 0, -7), thisMonday.AddDate(0, 0, -1)
+}
+
+// queryList returns the comma-separated list of values, each of them escaped.
+func queryList[T ~string](values []T) string {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = url.QueryEscape(string(v))
+	}
+	return strings.Join(escaped, ",")
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
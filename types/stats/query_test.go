@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatsQueryURL(t *testing.T) {
+	const prefix = "https://prod.datadev.ubisoft.com/v1/users/00000000-0000-0000-0000-000000000001/playerstats?spaceId=5172a557-50b5-4665-b7db-e3f2e8c5041d"
+
+	tests := []struct {
+		name    string
+		query   *StatsQuery
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "defaults",
+			query: NewStatsQuery().ForProvider(new(OperatorStats)),
+			want:  prefix + "&view=seasonal&aggregation=operators&gameMode=all,ranked,unranked,casual&platformGroup=PC&teamRole=all,Attacker,Defender",
+		},
+		{
+			name: "all filters",
+			query: NewStatsQuery().
+				ForProvider(new(OperatorStats)).
+				Seasons("Y8S1", "Y8S2").
+				GameModes(RANKED).
+				TeamRoles(ATTACKER).
+				Maps("CLUBHOUSE", "KAFE DOSTOYEVSKY").
+				Operators("Ash"),
+			want: prefix + "&view=seasonal&aggregation=operators&gameMode=ranked&platformGroup=PC&teamRole=Attacker" +
+				"&seasons=Y8S1,Y8S2&maps=CLUBHOUSE,KAFE+DOSTOYEVSKY&operators=Ash",
+		},
+		{
+			name: "date range",
+			query: NewStatsQuery().
+				ForProvider(new(SummarizedStats)).
+				DateRange(time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC), time.Date(2023, 7, 9, 0, 0, 0, 0, time.UTC)),
+			want: prefix + "&view=seasonal&aggregation=summary&gameMode=all,ranked,unranked,casual&platformGroup=PC&teamRole=all,Attacker,Defender" +
+				"&startDate=20230703&endDate=20230709",
+		},
+		{
+			name:  "explicit view",
+			query: NewStatsQuery().View("seasonal").ForProvider(new(WeaponStats)),
+			want:  prefix + "&view=seasonal&aggregation=weapons&gameMode=all,ranked,unranked,casual&platformGroup=PC&teamRole=all,Attacker,Defender",
+		},
+		{
+			name:    "missing aggregation",
+			query:   NewStatsQuery().View("current"),
+			wantErr: true,
+		},
+		{
+			name:  "explicit PC platform group",
+			query: NewStatsQuery().ForProvider(new(OperatorStats)).PlatformGroup(PC),
+			want:  prefix + "&view=seasonal&aggregation=operators&gameMode=all,ranked,unranked,casual&platformGroup=PC&teamRole=all,Attacker,Defender",
+		},
+		{
+			name:  "trend type",
+			query: NewStatsQuery().ForProvider(new(MovingTrendStats)).TrendType(TrendByDay),
+			want:  prefix + "&view=current&aggregation=movingpoint&gameMode=all,ranked,unranked,casual&platformGroup=PC&teamRole=all,Attacker,Defender&trendType=days",
+		},
+		{
+			name:    "trend type for unsupported aggregation",
+			query:   NewStatsQuery().ForProvider(new(SummarizedStats)).TrendType(TrendByDay),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported platform group",
+			query:   NewStatsQuery().ForProvider(new(OperatorStats)).PlatformGroup("CONSOLE"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query.URL("00000000-0000-0000-0000-000000000001")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("\nwant %s\ngot  %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStatsQueryClone(t *testing.T) {
+	q := NewStatsQuery().Maps("CLUBHOUSE")
+	c := q.Clone()
+	c.maps[0] = "BANK"
+	if q.maps[0] != "CLUBHOUSE" {
+		t.Error("modifying clone should not affect original")
+	}
+}
+
+func TestStatsQueryDateRange(t *testing.T) {
+	now := time.Date(2023, 7, 12, 15, 0, 0, 0, time.UTC)
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2023, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		query   *StatsQuery
+		wantErr bool
+	}{
+		{name: "valid", query: NewStatsQuery().DateRange(date(7, 3), date(7, 9))},
+		{name: "single day", query: NewStatsQuery().DateRange(date(7, 12), date(7, 12))},
+		{name: "long range", query: NewStatsQuery().DateRange(date(1, 1), date(7, 12))},
+		{name: "start after end", query: NewStatsQuery().DateRange(date(7, 9), date(7, 3)), wantErr: true},
+		{name: "future", query: NewStatsQuery().DateRange(date(7, 10), date(7, 13)), wantErr: true},
+		{name: "missing end", query: NewStatsQuery().DateRange(date(7, 3), time.Time{}), wantErr: true},
+		{name: "with seasons", query: NewStatsQuery().DateRange(date(7, 3), date(7, 9)).Seasons("Y8S2"), wantErr: true},
+		{name: "unsupported aggregation", query: NewStatsQuery().DateRange(date(7, 3), date(7, 9)).Aggregation("movingpoint"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.query
+			if q.aggregation == "" {
+				q = q.ForProvider(new(SummarizedStats))
+			} else {
+				q.View("current")
+			}
+			err := q.validate(now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidDateRange) {
+				t.Errorf("want ErrInvalidDateRange, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPreviousWeek(t *testing.T) {
+	wantMonday := time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)
+	wantSunday := time.Date(2023, 7, 9, 0, 0, 0, 0, time.UTC)
+
+	// every day of the following week, including its Sunday
+	for day := 10; day <= 16; day++ {
+		monday, sunday := PreviousWeek(time.Date(2023, 7, day, 18, 30, 0, 0, time.UTC))
+		if !monday.Equal(wantMonday) || !sunday.Equal(wantSunday) {
+			t.Errorf("July %d: want %s - %s, got %s - %s", day, wantMonday, wantSunday, monday, sunday)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SchemaVersion is the version of the public JSON schema written by Encode.
+// It is incremented whenever the schema changes in a way which is not backwards-compatible.
+//
+// Since version 1, the stats are wrapped in an envelope:
+//
+//	{"schemaVersion": 1, "aggregation": "operators", "view": "seasonal", "data": {...}}
+//
+// data contains one entry per game mode ("all", "casual", "unranked", "ranked"), omitting game modes without stats.
+// Their content is the JSON representation of the corresponding types of this package, e.g. NamedTeamRoles for OperatorStats.
+// All keys are camel-cased, DetailedStats additionally contain their derived Metrics in "metrics", which is ignored when decoding.
+const SchemaVersion = 1
+
+// Envelope is the top-level structure of the public JSON schema, see SchemaVersion.
+type Envelope struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Aggregation   string          `json:"aggregation"`
+	View          string          `json:"view"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// ErrUnsupportedSchemaVersion is returned by Decode if the data was encoded with a schema version other than SchemaVersion.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+
+// publicCodec is implemented by all providers of this package to convert them from and to the public JSON schema.
+// It is required since their UnmarshalJSON expects the Ubisoft API format.
+type publicCodec interface {
+	marshalPublic() ([]byte, error)
+	unmarshalPublic(data []byte) error
+}
+
+// Encode serializes the stats of p to the public JSON schema, which can be deserialized with Decode.
+// In contrast to the Ubisoft API format, this is stable across versions of this package (see SchemaVersion).
+func Encode(p Provider) ([]byte, error) {
+	codec, ok := p.(publicCodec)
+	if !ok {
+		return nil, fmt.Errorf("%T does not support the public schema", p)
+	}
+	data, err := codec.marshalPublic()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Envelope{
+		SchemaVersion: SchemaVersion,
+		Aggregation:   p.AggregationType(),
+		View:          p.ViewType(),
+		Data:          data,
+	})
+}
+
+// Decode deserializes data created by Encode into dst, which needs to be of the same provider type as the encoded one.
+func Decode(data []byte, dst Provider) error {
+	codec, ok := dst.(publicCodec)
+	if !ok {
+		return fmt.Errorf("%T does not support the public schema", dst)
+	}
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+	if envelope.SchemaVersion != SchemaVersion {
+		return fmt.Errorf("%w: %d (supported: %d)", ErrUnsupportedSchemaVersion, envelope.SchemaVersion, SchemaVersion)
+	}
+	if envelope.Aggregation != dst.AggregationType() {
+		return fmt.Errorf("cannot decode stats of aggregation '%s' into %T", envelope.Aggregation, dst)
+	}
+	return codec.unmarshalPublic(envelope.Data)
+}
+
+type publicGameModes[TGameMode any] struct {
+	All      *TGameMode `json:"all,omitempty"`
+	Casual   *TGameMode `json:"casual,omitempty"`
+	Unranked *TGameMode `json:"unranked,omitempty"`
+	Ranked   *TGameMode `json:"ranked,omitempty"`
+}
+
+func (l *statsLoader[TGameMode, TJSON]) marshalPublic() ([]byte, error) {
+	return json.Marshal(publicGameModes[TGameMode]{
+		All:      l.All,
+		Casual:   l.Casual,
+		Unranked: l.Unranked,
+		Ranked:   l.Ranked,
+	})
+}
+
+func (l *statsLoader[TGameMode, TJSON]) unmarshalPublic(data []byte) error {
+	var gameModes publicGameModes[TGameMode]
+	if err := json.Unmarshal(data, &gameModes); err != nil {
+		return err
+	}
+	l.All = gameModes.All
+	l.Casual = gameModes.Casual
+	l.Unranked = gameModes.Unranked
+	l.Ranked = gameModes.Ranked
+	return nil
+}
@@ -0,0 +1,107 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	operators := new(OperatorStats)
+	loadFixture(t, "testdata/operators_seasonal.json", operators)
+
+	mapStats := &MapStats{}
+	mapStats.Ranked = &map[string]NamedMapStatDetails{
+		"CLUBHOUSE": {
+			DetailedStats: DetailedStats{
+				reducedStats: reducedStats{Kills: 20, RoundsPlayed: 24},
+				matchStats:   matchStats{MatchesPlayed: 2, MatchesWon: 1, MatchesLost: 1},
+				Deaths:       15,
+			},
+			Attack: &DetailedStats{reducedStats: reducedStats{Kills: 12, RoundsPlayed: 12}},
+			Bombsites: &BombsiteGamemodeStats{
+				All: []BombsiteTeamRoleStats{
+					{Name: "2F Bar, 2F Cash", DetailedStats: DetailedStats{reducedStats: reducedStats{Kills: 8}}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		src  Provider
+		dst  Provider
+	}{
+		{name: "operators", src: operators, dst: new(OperatorStats)},
+		{name: "maps", src: mapStats, dst: new(MapStats)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Encode(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var envelope Envelope
+			if err = json.Unmarshal(data, &envelope); err != nil {
+				t.Fatal(err)
+			}
+			if envelope.SchemaVersion != SchemaVersion || envelope.Aggregation != tt.src.AggregationType() || envelope.View != tt.src.ViewType() {
+				t.Errorf("unexpected envelope: %+v", envelope)
+			}
+
+			if err = Decode(data, tt.dst); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.src, tt.dst) {
+				t.Errorf("decoded stats differ from encoded ones:\nwant %+v\ngot  %+v", tt.src, tt.dst)
+			}
+		})
+	}
+}
+
+func TestEncodeSchema(t *testing.T) {
+	s := &SummarizedStats{}
+	s.Ranked = &SummarizedGameModeStats{
+		All:        &DetailedStats{reducedStats: reducedStats{Kills: 10}, Deaths: 5, Season: "Y8S2"},
+		matchStats: matchStats{MatchesPlayed: 1},
+	}
+	data, err := Encode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"ranked":{"all":{`, `"kills":10`, `"deaths":5`, `"season":"Y8S2"`, `"metrics":{"kd":2,`, `"matchesPlayed":1`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("encoded stats do not contain %s: %s", want, data)
+		}
+	}
+	if strings.Contains(string(data), `"casual"`) {
+		t.Errorf("encoded stats should omit missing game modes: %s", data)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{name: "unsupported version", data: `{"schemaVersion":2,"aggregation":"summary","view":"seasonal","data":{}}`, wantErr: ErrUnsupportedSchemaVersion},
+		{name: "aggregation mismatch", data: `{"schemaVersion":1,"aggregation":"operators","view":"seasonal","data":{}}`},
+		{name: "malformed", data: `{"schemaVersion":1,"aggregation":"summary","view":"seasonal","data":{"ranked":[]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Decode([]byte(tt.data), new(SummarizedStats))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
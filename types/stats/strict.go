@@ -0,0 +1,267 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/stnokott/r6api/internal/keys"
+)
+
+// DecodeOptions configures how providers decode API responses.
+type DecodeOptions struct {
+	// Strict enables detection of schema changes: unknown fields, missing fields and unknown game mode or team role types
+	// are reported as DecodeIssues, available via DecodeIssues() after decoding.
+	// Entries of unknown types are skipped (see Decodable.UnknownVariants), so they do not fail decoding unless FailOnIssues is set.
+	// The known schema is derived from the response structs of this package, which are only tested against hand-written
+	// fixtures modelled on real responses, so issues might also indicate inaccuracies of the known schema.
+	Strict bool
+	// FailOnIssues makes decoding fail with a *DecodeError if any issues were detected.
+	// Only applicable in strict mode.
+	FailOnIssues bool
+	// KeepRaw keeps the raw response, available via Raw() after decoding.
+	// This allows archiving responses and decoding them again later, e.g. with newer versions of this package.
+	KeepRaw bool
+	// TrendType is the trend type the response was requested with, determining how positions of moving trend points are interpreted.
+	// Only applicable to MovingTrendStats, see StatsQuery.DecodeOptions to set it from a query.
+	TrendType TrendType
+}
+
+// Decodable is implemented by all providers of this package, allowing to configure how responses are decoded.
+type Decodable interface {
+	// SetDecodeOptions sets the options used for subsequent decoding.
+	SetDecodeOptions(opts DecodeOptions)
+	// DecodeIssues returns the issues detected while decoding the last response.
+	DecodeIssues() []DecodeIssue
+	// UnknownVariants returns the parts of the last response which were skipped due to their unknown type.
+	UnknownVariants() []UnknownVariant
+	// Raw returns the raw response decoded last if DecodeOptions.KeepRaw is set, nil otherwise.
+	// It contains multiple responses (in order) if other instances were merged into this one, see Merger.
+	// The raw responses can be decoded again into a new instance with json.Unmarshal.
+	Raw() []json.RawMessage
+}
+
+type DecodeIssueKind string
+
+const (
+	UnknownField DecodeIssueKind = "unknown field" // response contains a field which is not part of the known schema
+	MissingField DecodeIssueKind = "missing field" // response lacks a field which is expected to always be present
+	UnknownType  DecodeIssueKind = "unknown type"  // response contains a game mode or team role type which cannot be decoded and is skipped
+)
+
+// DecodeIssue describes a difference between a response and the known schema.
+type DecodeIssue struct {
+	Kind   DecodeIssueKind
+	Path   string // location in the response, e.g. "profileData.<id>.platforms.PC.gameModes.ranked.teamRoles.all[0].kills"
+	Detail string // additional information such as the unknown type, may be empty
+}
+
+func (i DecodeIssue) String() string {
+	if i.Detail == "" {
+		return fmt.Sprintf("%s at %s", i.Kind, i.Path)
+	}
+	return fmt.Sprintf("%s at %s: %s", i.Kind, i.Path, i.Detail)
+}
+
+// DecodeError is returned by providers in strict mode with DecodeOptions.FailOnIssues if any issues were detected.
+type DecodeError struct {
+	Issues []DecodeIssue
+}
+
+func (e *DecodeError) Error() string {
+	if len(e.Issues) == 1 {
+		return fmt.Sprintf("response does not match schema: %s", e.Issues[0])
+	}
+	return fmt.Sprintf("response does not match schema: %s (and %d more issues)", e.Issues[0], len(e.Issues)-1)
+}
+
+func (l *statsLoader[TGameMode, TJSON]) SetDecodeOptions(opts DecodeOptions) {
+	l.decodeOptions = opts
+}
+
+func (l *statsLoader[TGameMode, TJSON]) DecodeIssues() []DecodeIssue {
+	return l.decodeIssues
+}
+
+// Raw returns the raw response this instance was decoded from if DecodeOptions.KeepRaw is set, nil otherwise.
+// It contains multiple responses (in order) if other instances were merged into this one.
+// The raw responses can be decoded again into a new instance with json.Unmarshal.
+func (l *statsLoader[TGameMode, TJSON]) Raw() []json.RawMessage {
+	return l.raw
+}
+
+// ignoredFields contains fields which are part of the response schema, but not decoded.
+var ignoredFields = map[reflect.Type][]string{
+	reflect.TypeOf(ubiProfileDataJSON{}):   {"isPrivate", "isBanned"},
+	reflect.TypeOf(ubiJSONFloat{}):         {"p"},
+	reflect.TypeOf(ubiDetailedStatsJSON{}): {"killDeathRatio", "winLossRatio"},
+}
+
+var (
+	typedGameModeType = reflect.TypeOf(ubiTypedGameModeJSON{})
+	typedTeamRoleType = reflect.TypeOf(ubiTypedTeamRoleJSON{})
+)
+
+// checkSchema compares data against the response schema, returning all detected issues.
+func checkSchema(data []byte) ([]DecodeIssue, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	c := new(schemaChecker)
+	c.check("", raw, reflect.TypeOf(ubiStatsResponseJSON{}))
+	return c.issues, nil
+}
+
+type schemaChecker struct {
+	issues []DecodeIssue
+}
+
+type schemaField struct {
+	typ      reflect.Type
+	required bool
+}
+
+func (c *schemaChecker) report(kind DecodeIssueKind, path string, detail string) {
+	c.issues = append(c.issues, DecodeIssue{Kind: kind, Path: path, Detail: detail})
+}
+
+// check recursively compares v (as decoded into any) against t.
+// Pointer, slice and map fields are optional, all other fields are expected to be present.
+func (c *schemaChecker) check(path string, v any, t reflect.Type) {
+	if v == nil {
+		return
+	}
+	switch t {
+	case typedGameModeType:
+		c.checkTyped(path, v, reflect.TypeOf(ubiGameModeStatsTypeJSON{}), func(typ string) (any, bool) {
+			return newGameModeValue(gameModeStatsType(typ))
+		})
+		return
+	case typedTeamRoleType:
+		c.checkTyped(path, v, reflect.TypeOf(ubiTeamRoleStatsTypeJSON{}), func(typ string) (any, bool) {
+			return newTeamRoleValue(teamRoleStatsType(typ))
+		})
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		c.check(path, v, t.Elem())
+	case reflect.Struct:
+		c.checkObject(path, v, schemaFields(t))
+	case reflect.Slice:
+		if arr, ok := v.([]any); ok {
+			for i, elem := range arr {
+				c.check(fmt.Sprintf("%s[%d]", path, i), elem, t.Elem())
+			}
+		}
+	case reflect.Map:
+		if obj, ok := v.(map[string]any); ok {
+			for _, key := range keys.Sorted(obj) {
+				c.check(joinPath(path, key), obj[key], t.Elem())
+			}
+		}
+	}
+}
+
+// checkTyped checks an object whose schema depends on its "type" field.
+func (c *schemaChecker) checkTyped(path string, v any, header reflect.Type, newValue func(string) (any, bool)) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	typ, _ := obj["type"].(string)
+	value, ok := newValue(typ)
+	if !ok {
+		c.report(UnknownType, joinPath(path, "type"), typ)
+		return
+	}
+	fields := schemaFields(header)
+	for name, field := range schemaFields(reflect.TypeOf(value).Elem()) {
+		fields[name] = field
+	}
+	c.checkObject(path, obj, fields)
+}
+
+// checkObject checks the keys of v against fields.
+// Like encoding/json, keys match fields case-insensitively if no field matches exactly.
+func (c *schemaChecker) checkObject(path string, v any, fields map[string]schemaField) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	names := keys.Sorted(fields)
+	present := make(map[string]bool, len(obj))
+	for _, key := range keys.Sorted(obj) {
+		name, known := matchField(key, names)
+		if !known {
+			c.report(UnknownField, joinPath(path, key), "")
+			continue
+		}
+		present[name] = true
+		if field := fields[name]; field.typ != nil {
+			c.check(joinPath(path, key), obj[key], field.typ)
+		}
+	}
+	for _, name := range names {
+		if !present[name] && fields[name].required {
+			c.report(MissingField, joinPath(path, name), "")
+		}
+	}
+}
+
+// matchField returns the name among names key is decoded into by encoding/json,
+// preferring an exact match over a case-insensitive one.
+func matchField(key string, names []string) (string, bool) {
+	match, found := "", false
+	for _, name := range names {
+		if name == key {
+			return name, true
+		}
+		if !found && strings.EqualFold(name, key) {
+			match, found = name, true
+		}
+	}
+	return match, found
+}
+
+// schemaFields returns the fields of struct type t by their JSON name, including fields of embedded structs and ignored fields.
+func schemaFields(t reflect.Type) map[string]schemaField {
+	fields := map[string]schemaField{}
+	for _, name := range ignoredFields[t] {
+		fields[name] = schemaField{}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for embeddedName, embeddedField := range schemaFields(f.Type) {
+				fields[embeddedName] = embeddedField
+			}
+			continue
+		}
+		if !f.IsExported() || name == "" {
+			// untagged fields (such as the decoded value of typed structs) are not part of the schema
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			fields[name] = schemaField{typ: f.Type}
+		default:
+			fields[name] = schemaField{typ: f.Type, required: true}
+		}
+	}
+	return fields
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,220 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+const strictEntryPath = "profileData.00000000-0000-0000-0000-000000000001.platforms.PC.gameModes.all.teamRoles.all[0]"
+
+// modifiedFixture returns the operators fixture with modify applied to the first entry of all game modes and team roles.
+func modifiedFixture(t *testing.T, modify func(entry map[string]any)) []byte {
+	t.Helper()
+	data, err := os.ReadFile(sharedTestdata + "operators.json")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+	var raw map[string]any
+	if err = json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	userID := raw["userId"].(string)
+	profile := raw["profileData"].(map[string]any)[userID].(map[string]any)
+	gameMode := profile["platforms"].(map[string]any)["PC"].(map[string]any)["gameModes"].(map[string]any)["all"].(map[string]any)
+	modify(gameMode["teamRoles"].(map[string]any)["all"].([]any)[0].(map[string]any))
+	if data, err = json.Marshal(raw); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestStrictDecoding(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(entry map[string]any)
+		wantIssues []DecodeIssue
+	}{
+		{
+			name:   "matching schema",
+			modify: func(map[string]any) {},
+		},
+		{
+			name: "unknown and missing fields",
+			modify: func(entry map[string]any) {
+				entry["roundsWithPlant"] = map[string]any{"value": 0.1, "p": 0}
+				delete(entry, "kills")
+				entry["headshotAccuracy"] = map[string]any{"val": 0.5}
+			},
+			wantIssues: []DecodeIssue{
+				{Kind: UnknownField, Path: strictEntryPath + ".headshotAccuracy.val"},
+				{Kind: MissingField, Path: strictEntryPath + ".headshotAccuracy.value"},
+				{Kind: UnknownField, Path: strictEntryPath + ".roundsWithPlant"},
+				{Kind: MissingField, Path: strictEntryPath + ".kills"},
+			},
+		},
+		{
+			name: "case-insensitive key",
+			modify: func(entry map[string]any) {
+				// encoding/json decodes keys differing in case only, so they are not reported
+				entry["roundsWithMultikill"] = entry["roundsWithMultiKill"]
+				delete(entry, "roundsWithMultiKill")
+			},
+		},
+		{
+			name: "unknown type",
+			modify: func(entry map[string]any) {
+				entry["type"] = "Percentiles"
+			},
+			wantIssues: []DecodeIssue{
+				{Kind: UnknownType, Path: strictEntryPath + ".type", Detail: "Percentiles"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := modifiedFixture(t, tt.modify)
+
+			s := new(OperatorStats)
+			s.SetDecodeOptions(DecodeOptions{Strict: true})
+			if err := json.Unmarshal(data, s); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(s.DecodeIssues(), tt.wantIssues) {
+				t.Errorf("want issues %v, got %v", tt.wantIssues, s.DecodeIssues())
+			}
+
+			s = new(OperatorStats)
+			s.SetDecodeOptions(DecodeOptions{Strict: true, FailOnIssues: true})
+			err := json.Unmarshal(data, s)
+			var decodeErr *DecodeError
+			if isDecodeErr := errors.As(err, &decodeErr); isDecodeErr != (len(tt.wantIssues) > 0) {
+				t.Errorf("unexpected error %v", err)
+			}
+
+			s = new(OperatorStats)
+			if err = json.Unmarshal(data, s); err != nil {
+				t.Errorf("non-strict: unexpected error %v", err)
+			}
+			if len(s.DecodeIssues()) > 0 {
+				t.Errorf("non-strict: want no issues, got %v", s.DecodeIssues())
+			}
+		})
+	}
+}
+
+func TestStrictDecodingAPISpelling(t *testing.T) {
+	// the fixture only contains the keys as spelled by the API, e.g. "roundsWithMultiKill" and "roundsWithAnAce"
+	data := modifiedFixture(t, func(entry map[string]any) {
+		entry["roundsWithAnAce"] = map[string]any{"value": 0.025, "p": 0}
+	})
+	s := new(OperatorStats)
+	s.SetDecodeOptions(DecodeOptions{Strict: true, FailOnIssues: true})
+	if err := json.Unmarshal(data, s); err != nil {
+		t.Fatal(err)
+	}
+	ash := s.All.All["Ash"]
+	assertFloat(t, "roundsWithMultikill", 0.15, ash.RoundsWithMultikill)
+	assertFloat(t, "roundsWithAce", 0.025, ash.RoundsWithAce)
+}
+
+func TestStrictDecodingFixtures(t *testing.T) {
+	tests := []struct {
+		fixture string
+		dst     Provider
+	}{
+		{fixture: "summary.json", dst: new(SummarizedStats)},
+		{fixture: "operators.json", dst: new(OperatorStats)},
+		{fixture: "maps.json", dst: new(MapStats)},
+		{fixture: "bombsites.json", dst: new(BombsiteStats)},
+		{fixture: "weapons.json", dst: new(WeaponStats)},
+		{fixture: "movingpoint.json", dst: new(MovingTrendStats)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			d := tt.dst.(Decodable)
+			d.SetDecodeOptions(DecodeOptions{Strict: true, TrendType: TrendByMatch})
+			loadFixture(t, sharedTestdata+tt.fixture, tt.dst)
+			if issues := d.DecodeIssues(); len(issues) > 0 {
+				t.Errorf("want no issues, got %v", issues)
+			}
+		})
+	}
+}
+
+func TestUnknownVariants(t *testing.T) {
+	data := modifiedFixture(t, func(entry map[string]any) {
+		entry["type"] = "Percentiles"
+	})
+
+	s := new(OperatorStats)
+	if err := json.Unmarshal(data, s); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.All.All["Ash"]; ok {
+		t.Error("entry with unknown type should be skipped")
+	}
+	if _, ok := s.All.All["Jäger"]; !ok {
+		t.Error("entries with known type should be decoded")
+	}
+
+	unknown := s.UnknownVariants()
+	if len(unknown) != 1 {
+		t.Fatalf("want 1 unknown variant, got %d", len(unknown))
+	}
+	if unknown[0].GameMode != ALL || unknown[0].TeamRole != ALL_ROLES || unknown[0].Type != "Percentiles" {
+		t.Errorf("unexpected unknown variant: %+v", unknown[0])
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(unknown[0].Raw, &raw); err != nil || raw["statsDetail"] != "Ash" {
+		t.Errorf("unexpected raw JSON: %s", unknown[0].Raw)
+	}
+}
+
+func TestStrictDecodingUnknownGameModeType(t *testing.T) {
+	data, err := os.ReadFile(sharedTestdata + "operators.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err = json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	userID := raw["userId"].(string)
+	gameModes := raw["profileData"].(map[string]any)[userID].(map[string]any)["platforms"].(map[string]any)["PC"].(map[string]any)["gameModes"].(map[string]any)
+	gameModes["casual"] = map[string]any{"type": "Team roles percentiles", "teamRoles": map[string]any{}}
+	if data, err = json.Marshal(raw); err != nil {
+		t.Fatal(err)
+	}
+
+	// an unknown type is reported without failing the response unless requested
+	s := new(OperatorStats)
+	s.SetDecodeOptions(DecodeOptions{Strict: true})
+	if err = json.Unmarshal(data, s); err != nil {
+		t.Fatal(err)
+	}
+	want := []DecodeIssue{{
+		Kind:   UnknownType,
+		Path:   "profileData." + userID + ".platforms.PC.gameModes.casual.type",
+		Detail: "Team roles percentiles",
+	}}
+	if !reflect.DeepEqual(s.DecodeIssues(), want) {
+		t.Errorf("want issues %v, got %v", want, s.DecodeIssues())
+	}
+	if s.All == nil || s.Casual != nil {
+		t.Error("only the game mode with unknown type should be skipped")
+	}
+	if unknown := s.UnknownVariants(); len(unknown) != 1 || unknown[0].GameMode != CASUAL || unknown[0].TeamRole != "" {
+		t.Errorf("unexpected unknown variants %+v", unknown)
+	}
+
+	s = new(OperatorStats)
+	s.SetDecodeOptions(DecodeOptions{Strict: true, FailOnIssues: true})
+	var decodeErr *DecodeError
+	if err = json.Unmarshal(data, s); !errors.As(err, &decodeErr) || decodeErr.Issues[0].Kind != UnknownType {
+		t.Errorf("want *DecodeError with unknown type, got %v", err)
+	}
+}
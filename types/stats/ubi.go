@@ -3,29 +3,60 @@ package stats
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"text/template"
 )
 
+// UbiStatsURLTemplate is the template of stats request URLs.
+//
+// Deprecated: use StatsQuery, which supports all filters of the endpoint.
 var UbiStatsURLTemplate = template.Must(template.New("statsURL").Parse(
-	"https://prod.datadev.ubisoft.com/v1/users/{{urlquery .ProfileID}}/playerstats?spaceId=5172a557-50b5-4665-b7db-e3f2e8c5041d&view={{urlquery .View}}&aggregation={{urlquery .Aggregation}}&gameMode=all,ranked,unranked,casual&platformGroup=PC&teamRole=all,Attacker,Defender&seasons={{urlquery .Season}}",
+	"https://prod.datadev.ubisoft.com/v1/users/{{urlquery .ProfileID}}/playerstats?spaceId=5172a557-50b5-4665-b7db-e3f2e8c5041d&view={{urlquery .View}}&aggregation={{urlquery .Aggregation}}&gameMode={{.GameModesParam}}&platformGroup=PC&teamRole={{.TeamRolesParam}}&seasons={{urlquery .Season}}",
 ))
 
+// UbiStatsURLParams contains parameters for UbiStatsURLTemplate.
+// GameModes and TeamRoles are optional, all of them are requested if empty.
+//
+// Deprecated: use StatsQuery, which supports all filters of the endpoint.
 type UbiStatsURLParams struct {
 	ProfileID   string
 	Aggregation string
 	View        string
 	Season      string
+	GameModes   []GameMode
+	TeamRoles   []TeamRole
+}
+
+// GameModesParam returns a query string used in UbiStatsURLTemplate and should not be called directly.
+func (p UbiStatsURLParams) GameModesParam() string {
+	gameModes := p.GameModes
+	if len(gameModes) == 0 {
+		gameModes = []GameMode{ALL, RANKED, UNRANKED, CASUAL}
+	}
+	return queryList(gameModes)
+}
+
+// TeamRolesParam returns a query string used in UbiStatsURLTemplate and should not be called directly.
+func (p UbiStatsURLParams) TeamRolesParam() string {
+	teamRoles := p.TeamRoles
+	if len(teamRoles) == 0 {
+		teamRoles = []TeamRole{ALL_ROLES, ATTACKER, DEFENDER}
+	}
+	return queryList(teamRoles)
 }
 
 type ubiStatsResponseJSON struct {
-	ProfileData map[string]struct {
-		Platforms struct {
-			PC struct {
-				GameModes ubiGameModesJSON `json:"gameModes"`
-			} `json:"PC"`
-		} `json:"platforms"`
-	} `json:"profileData"`
-	UserID string `json:"userId"`
+	ProfileData map[string]ubiProfileDataJSON `json:"profileData"`
+	UserID      string                        `json:"userId"`
+}
+
+type ubiProfileDataJSON struct {
+	Platforms struct {
+		PC struct {
+			GameModes ubiGameModesJSON `json:"gameModes"`
+		} `json:"PC"`
+	} `json:"platforms"`
 }
 
 type ubiGameModesJSON struct {
@@ -35,6 +66,12 @@ type ubiGameModesJSON struct {
 	StatsRanked   *ubiTypedGameModeJSON `json:"ranked"`
 }
 
+// byGameMode returns the game modes in the order all, casual, unranked, ranked together with their stats, which are nil if not contained in the response.
+func (u ubiGameModesJSON) byGameMode() ([]GameMode, []*ubiTypedGameModeJSON) {
+	return []GameMode{ALL, CASUAL, UNRANKED, RANKED},
+		[]*ubiTypedGameModeJSON{u.StatsAll, u.StatsCasual, u.StatsUnranked, u.StatsRanked}
+}
+
 /********************
 Game Mode Stats Types
 *********************/
@@ -61,17 +98,26 @@ func (u *ubiTypedGameModeJSON) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	switch typed.Type {
+	u.Type = typed.Type
+	var ok bool
+	if u.Value, ok = newGameModeValue(typed.Type); !ok {
+		// keep unknown types as raw JSON so they can be skipped instead of failing the whole response
+		u.Value = append(json.RawMessage(nil), data...)
+		return nil
+	}
+	return json.Unmarshal(data, u.Value)
+}
+
+// newGameModeValue returns a pointer to the struct holding game mode stats of type t.
+func newGameModeValue(t gameModeStatsType) (any, bool) {
+	switch t {
 	case typeTeamRoles, "":
-		u.Value = new(ubiTeamRolesJSON)
+		return new(ubiTeamRolesJSON), true
 	case typeTeamRoleWeapons:
-		u.Value = new(ubiGameModeWeaponsJSON)
+		return new(ubiGameModeWeaponsJSON), true
 	default:
-		return fmt.Errorf("encountered unknown game mode type: '%s'", typed.Type)
+		return nil, false
 	}
-	err := json.Unmarshal(data, u.Value)
-	u.Type = typed.Type
-	return err
 }
 
 /********************
@@ -89,6 +135,34 @@ type ubiTeamRolesJSON struct {
 	} `json:"teamRoles"`
 }
 
+// byTeamRole returns the team roles in the order all, Attacker, Defender together with pointers to their entries.
+func (u *ubiTeamRolesJSON) byTeamRole() ([]TeamRole, []*[]ubiTypedTeamRoleJSON) {
+	return []TeamRole{ALL_ROLES, ATTACKER, DEFENDER},
+		[]*[]ubiTypedTeamRoleJSON{&u.TeamRoles.All, &u.TeamRoles.Attack, &u.TeamRoles.Defence}
+}
+
+// removeUnknown removes all entries with unknown types, returning them.
+func (u *ubiTeamRolesJSON) removeUnknown(gameMode GameMode) (unknown []UnknownVariant) {
+	teamRoleNames, teamRoles := u.byTeamRole()
+	for i, teamRole := range teamRoles {
+		known := (*teamRole)[:0]
+		for _, entry := range *teamRole {
+			if raw, isRaw := entry.Value.(json.RawMessage); isRaw {
+				unknown = append(unknown, UnknownVariant{
+					GameMode: gameMode,
+					TeamRole: teamRoleNames[i],
+					Type:     string(entry.Type),
+					Raw:      raw,
+				})
+				continue
+			}
+			known = append(known, entry)
+		}
+		*teamRole = known
+	}
+	return
+}
+
 type teamRoleStatsType string
 
 const (
@@ -105,6 +179,7 @@ type ubiTeamRoleStatsTypeJSON struct {
 type ubiTypedTeamRoleJSON struct {
 	ubiTeamRoleStatsTypeJSON
 	Value any
+	raw   json.RawMessage // JSON of the entry, see TeamRoleEntry.Raw
 }
 
 func (u *ubiTypedTeamRoleJSON) UnmarshalJSON(data []byte) error {
@@ -113,17 +188,27 @@ func (u *ubiTypedTeamRoleJSON) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	switch typed.Type {
+	u.ubiTeamRoleStatsTypeJSON = typed
+	u.raw = append(json.RawMessage(nil), data...)
+	var ok bool
+	if u.Value, ok = newTeamRoleValue(typed.Type); !ok {
+		// keep unknown types as raw JSON so they can be skipped instead of failing the whole response
+		u.Value = u.raw
+		return nil
+	}
+	return json.Unmarshal(data, u.Value)
+}
+
+// newTeamRoleValue returns a pointer to the struct holding team role stats of type t.
+func newTeamRoleValue(t teamRoleStatsType) (any, bool) {
+	switch t {
 	case typeGeneralized, typeSeasonal:
-		u.Value = new(ubiDetailedStatsJSON)
+		return new(ubiDetailedStatsJSON), true
 	case typeMovingPoint:
-		u.Value = new(ubiMovingTrendJSON)
+		return new(ubiMovingTrendJSON), true
 	default:
-		return fmt.Errorf("encountered unknown team role type: '%s'", typed.Type)
+		return nil, false
 	}
-	err := json.Unmarshal(data, u.Value)
-	u.ubiTeamRoleStatsTypeJSON = typed
-	return err
 }
 
 /*
@@ -139,6 +224,12 @@ type ubiGameModeWeaponsJSON struct {
 	} `json:"teamRoles"`
 }
 
+// byTeamRole returns the team roles in the order all, Attacker, Defender together with their weapon slots, which are nil if not contained in the response.
+func (u *ubiGameModeWeaponsJSON) byTeamRole() ([]TeamRole, []*ubiWeaponSlotsJSON) {
+	return []TeamRole{ALL_ROLES, ATTACKER, DEFENDER},
+		[]*ubiWeaponSlotsJSON{u.TeamRoles.All, u.TeamRoles.Attack, u.TeamRoles.Defence}
+}
+
 type ubiWeaponSlotsJSON struct {
 	WeaponSlots struct {
 		Primary   *ubiWeaponTypesJSON `json:"primaryWeapons"`
@@ -155,10 +246,22 @@ type ubiWeaponTypesJSON struct {
 
 type ubiWeaponStatsJSON struct {
 	WeaponName string `json:"weaponName"`
+	ubiSeasonInfo
 	ubiReducedStatsJSON
 	RoundsWithKill      float64 `json:"roundsWithAKill"`
 	RoundsWithMultikill float64 `json:"roundsWithMultikill"`
 	HeadshotPercentage  float64 `json:"headshotAccuracy"`
+
+	raw json.RawMessage // JSON of the entry, see TeamRoleEntry.Raw
+}
+
+func (u *ubiWeaponStatsJSON) UnmarshalJSON(data []byte) error {
+	type plain ubiWeaponStatsJSON
+	if err := json.Unmarshal(data, (*plain)(u)); err != nil {
+		return err
+	}
+	u.raw = append(json.RawMessage(nil), data...)
+	return nil
 }
 
 /***************************
@@ -189,7 +292,23 @@ type ubiMovingTrendEntryJSON struct {
 	Trend   ubiMovingTrendPoints `json:"trend"`
 }
 
-type ubiMovingTrendPoints map[int]float64
+// ubiMovingTrendPoints maps the position of a point to its value.
+// Positions are match numbers starting at 1 or dates in the format YYYYMMDD, depending on the requested TrendType.
+type ubiMovingTrendPoints map[string]float64
+
+func (p *ubiMovingTrendPoints) UnmarshalJSON(data []byte) error {
+	var points map[string]float64
+	if err := json.Unmarshal(data, &points); err != nil {
+		return err
+	}
+	for key := range points {
+		if _, err := strconv.Atoi(key); err != nil {
+			return fmt.Errorf("invalid moving trend position '%s'", key)
+		}
+	}
+	*p = points
+	return nil
+}
 
 /***************
 Generic structs
@@ -229,8 +348,8 @@ type ubiDetailedStatsJSON struct {
 	Revives              int          `json:"revives"`
 	RoundsSurvived       ubiJSONFloat `json:"roundsSurvived"`
 	RoundsWithKill       ubiJSONFloat `json:"roundsWithAKill"`
-	RoundsWithMultikill  ubiJSONFloat `json:"roundsWithMultikill"`
-	RoundsWithAce        ubiJSONFloat `json:"roundsWithAce"`
+	RoundsWithMultikill  ubiJSONFloat `json:"roundsWithMultiKill"`
+	RoundsWithAce        ubiJSONFloat `json:"roundsWithAnAce"`
 	RoundsWithClutch     ubiJSONFloat `json:"roundsWithClutch"`
 	RoundsWithKOST       ubiJSONFloat `json:"roundsWithKOST"`
 	RoundsWithEntryDeath ubiJSONFloat `json:"roundsWithOpeningDeath"`
@@ -245,3 +364,13 @@ type ubiSeasonInfo struct {
 	SeasonYear   *string `json:"seasonYear"`
 	SeasonNumber *string `json:"seasonNumber"`
 }
+
+// slug returns the season slug (e.g. "Y8S2") or "" if no season information is available.
+func (s ubiSeasonInfo) slug() string {
+	if s.SeasonYear == nil || s.SeasonNumber == nil {
+		return ""
+	}
+	year := strings.TrimPrefix(*s.SeasonYear, "Y")
+	number := strings.TrimPrefix(*s.SeasonNumber, "S")
+	return "Y" + year + "S" + number
+}
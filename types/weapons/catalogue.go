@@ -0,0 +1,204 @@
+package weapons
+
+// weaponTypes maps every weapon to its type as named in weapon stats responses.
+var weaponTypes = map[string]string{
+	// assault rifles
+	"416-C CARBINE": AssaultRifle,
+	"552 COMMANDO":  AssaultRifle,
+	"556XI":         AssaultRifle,
+	"AK-12":         AssaultRifle,
+	"AK-74M":        AssaultRifle,
+	"AR33":          AssaultRifle,
+	"ARX200":        AssaultRifle,
+	"AUG A2":        AssaultRifle,
+	"C7E":           AssaultRifle,
+	"C8-SFW":        AssaultRifle,
+	"COMMANDO 9":    AssaultRifle,
+	"F2":            AssaultRifle,
+	"F90":           AssaultRifle,
+	"G36C":          AssaultRifle,
+	"L85A2":         AssaultRifle,
+	"M4":            AssaultRifle,
+	"M762":          AssaultRifle,
+	"MK17 CQB":      AssaultRifle,
+	"PARA-308":      AssaultRifle,
+	"POF-9":         AssaultRifle,
+	"R4-C":          AssaultRifle,
+	"SC3000K":       AssaultRifle,
+	"SPEAR .308":    AssaultRifle,
+	"TYPE-89":       AssaultRifle,
+	"V308":          AssaultRifle,
+
+	// submachine guns
+	"9MM C1":            SubmachineGun,
+	"9X19VSN":           SubmachineGun,
+	"AUG A3":            SubmachineGun,
+	"FMG-9":             SubmachineGun,
+	"K1A":               SubmachineGun,
+	"M12":               SubmachineGun,
+	"MP5":               SubmachineGun,
+	"MP5K":              SubmachineGun,
+	"MP5SD":             SubmachineGun,
+	"MP7":               SubmachineGun,
+	"MPX":               SubmachineGun,
+	"MX4 STORM":         SubmachineGun,
+	"P10 RONI":          SubmachineGun,
+	"P90":               SubmachineGun,
+	"PDW9":              SubmachineGun,
+	"SCORPION EVO 3 A1": SubmachineGun,
+	"T-5 SMG":           SubmachineGun,
+	"UMP45":             SubmachineGun,
+	"UZK50GI":           SubmachineGun,
+	"VECTOR .45 ACP":    SubmachineGun,
+
+	// shotguns
+	"ACS12":        Shotgun,
+	"BOSG.12.2":    Shotgun,
+	"FO-12":        Shotgun,
+	"ITA12L":       Shotgun,
+	"ITA12S":       Shotgun,
+	"M1014":        Shotgun,
+	"M590A1":       Shotgun,
+	"M870":         Shotgun,
+	"SASG-12":      Shotgun,
+	"SG-CQB":       Shotgun,
+	"SIX12":        Shotgun,
+	"SIX12 SD":     Shotgun,
+	"SPAS-12":      Shotgun,
+	"SPAS-15":      Shotgun,
+	"SUPER 90":     Shotgun,
+	"SUPER SHORTY": Shotgun,
+	"SUPERNOVA":    Shotgun,
+	"TCSG12":       Shotgun,
+
+	// marksman rifles
+	"417":       MarksmanRifle,
+	"AR-15.50":  MarksmanRifle,
+	"CAMRS":     MarksmanRifle,
+	"CSRX 300":  MarksmanRifle,
+	"MK 14 EBR": MarksmanRifle,
+	"OTS-03":    MarksmanRifle,
+	"SR-25":     MarksmanRifle,
+
+	// light machine guns
+	"6P41":      LightMachineGun,
+	"ALDA 5.56": LightMachineGun,
+	"DP27":      LightMachineGun,
+	"G8A1":      LightMachineGun,
+	"LMG-E":     LightMachineGun,
+	"M249":      LightMachineGun,
+	"M249 SAW":  LightMachineGun,
+	"T-95 LSW":  LightMachineGun,
+
+	// handguns
+	".44 MAG SEMI-AUTO": Handgun,
+	".44 VENDETTA":      Handgun,
+	"1911 TACOPS":       Handgun,
+	"5.7 USG":           Handgun,
+	"BAILIFF 410":       Handgun,
+	"D-50":              Handgun,
+	"GONNE-6":           Handgun,
+	"GSH-18":            Handgun,
+	"KERATOS .357":      Handgun,
+	"LFP586":            Handgun,
+	"LUISON":            Handgun,
+	"M45 MEUSOC":        Handgun,
+	"MK1 9MM":           Handgun,
+	"P-10C":             Handgun,
+	"P12":               Handgun,
+	"P226 MK 25":        Handgun,
+	"P229":              Handgun,
+	"P9":                Handgun,
+	"PMM":               Handgun,
+	"PRB92":             Handgun,
+	"Q-929":             Handgun,
+	"RG15":              Handgun,
+	"SDP 9MM":           Handgun,
+	"USP40":             Handgun,
+
+	// machine pistols
+	"BEARING 9": MachinePistol,
+	"C75 AUTO":  MachinePistol,
+	"SMG-11":    MachinePistol,
+	"SMG-12":    MachinePistol,
+	"SPSMG9":    MachinePistol,
+}
+
+// loadouts contains the loadouts of all operators up to and including Deimos (released in Y9S1), excluding shields and gadgets.
+// They are compiled by hand and not tied to a specific game patch, i.e. loadout changes after Y9S1 and operators released since then are missing.
+var loadouts = []Loadout{
+	// attackers
+	{Operator: "Sledge", Attacker: true, Primaries: []string{"L85A2", "M590A1"}, Secondaries: []string{"P226 MK 25", "SMG-11"}},
+	{Operator: "Thatcher", Attacker: true, Primaries: []string{"AR33", "L85A2", "M590A1"}, Secondaries: []string{"P226 MK 25"}},
+	{Operator: "Ash", Attacker: true, Primaries: []string{"G36C", "R4-C"}, Secondaries: []string{"5.7 USG", "M45 MEUSOC"}},
+	{Operator: "Thermite", Attacker: true, Primaries: []string{"556XI", "M1014"}, Secondaries: []string{"5.7 USG", "M45 MEUSOC"}},
+	{Operator: "Twitch", Attacker: true, Primaries: []string{"F2", "417", "SG-CQB"}, Secondaries: []string{"P9", "LFP586"}},
+	{Operator: "Montagne", Attacker: true, Secondaries: []string{"P9", "LFP586"}},
+	{Operator: "Glaz", Attacker: true, Primaries: []string{"OTs-03"}, Secondaries: []string{"PMM", "GSh-18", "Bearing 9"}},
+	{Operator: "Fuze", Attacker: true, Primaries: []string{"6P41", "AK-12"}, Secondaries: []string{"PMM", "GSh-18"}},
+	{Operator: "Blitz", Attacker: true, Secondaries: []string{"P12"}},
+	{Operator: "IQ", Attacker: true, Primaries: []string{"AUG A2", "552 Commando", "G8A1"}, Secondaries: []string{"P12"}},
+	{Operator: "Buck", Attacker: true, Primaries: []string{"C8-SFW", "CAMRS"}, Secondaries: []string{"MK1 9mm", "Gonne-6"}},
+	{Operator: "Blackbeard", Attacker: true, Primaries: []string{"MK17 CQB", "SR-25"}, Secondaries: []string{"D-50"}},
+	{Operator: "Capitão", Attacker: true, Primaries: []string{"PARA-308", "M249"}, Secondaries: []string{"PRB92", "Gonne-6"}},
+	{Operator: "Hibana", Attacker: true, Primaries: []string{"TYPE-89", "SUPERNOVA"}, Secondaries: []string{"P229", "Bearing 9"}},
+	{Operator: "Jackal", Attacker: true, Primaries: []string{"C7E", "PDW9", "ITA12L"}, Secondaries: []string{"USP40", "ITA12S"}},
+	{Operator: "Ying", Attacker: true, Primaries: []string{"SIX12", "T-95 LSW"}, Secondaries: []string{"Q-929"}},
+	{Operator: "Zofia", Attacker: true, Primaries: []string{"LMG-E", "M762"}, Secondaries: []string{"RG15"}},
+	{Operator: "Dokkaebi", Attacker: true, Primaries: []string{"Mk 14 EBR", "BOSG.12.2"}, Secondaries: []string{"SMG-12", "C75 Auto", "Gonne-6"}},
+	{Operator: "Lion", Attacker: true, Primaries: []string{"V308", "417", "SG-CQB"}, Secondaries: []string{"LFP586", "P9"}},
+	{Operator: "Finka", Attacker: true, Primaries: []string{"Spear .308", "6P41", "SASG-12"}, Secondaries: []string{"PMM", "GSh-18"}},
+	{Operator: "Maverick", Attacker: true, Primaries: []string{"AR-15.50", "M4"}, Secondaries: []string{"1911 TACOPS"}},
+	{Operator: "Nomad", Attacker: true, Primaries: []string{"AK-74M", "ARX200"}, Secondaries: []string{".44 Mag Semi-Auto", "PRB92"}},
+	{Operator: "Gridlock", Attacker: true, Primaries: []string{"F90", "M249 SAW"}, Secondaries: []string{"Super Shorty", "SDP 9mm"}},
+	{Operator: "Nøkk", Attacker: true, Primaries: []string{"FMG-9", "SIX12 SD"}, Secondaries: []string{"5.7 USG", "D-50"}},
+	{Operator: "Amaru", Attacker: true, Primaries: []string{"G8A1", "SUPERNOVA"}, Secondaries: []string{"ITA12S", "SMG-11"}},
+	{Operator: "Kali", Attacker: true, Primaries: []string{"CSRX 300"}, Secondaries: []string{"C75 Auto", "SPSMG9", "P226 MK 25"}},
+	{Operator: "Iana", Attacker: true, Primaries: []string{"ARX200", "G36C"}, Secondaries: []string{"MK1 9mm", "Gonne-6"}},
+	{Operator: "Ace", Attacker: true, Primaries: []string{"AK-12", "M1014"}, Secondaries: []string{"P9"}},
+	{Operator: "Zero", Attacker: true, Primaries: []string{"SC3000K", "MP7"}, Secondaries: []string{"5.7 USG", "Gonne-6"}},
+	{Operator: "Flores", Attacker: true, Primaries: []string{"AR33", "SR-25"}, Secondaries: []string{"GSh-18"}},
+	{Operator: "Osa", Attacker: true, Primaries: []string{"556XI", "PDW9"}, Secondaries: []string{"PMM"}},
+	{Operator: "Sens", Attacker: true, Primaries: []string{"POF-9", "417"}, Secondaries: []string{"SDP 9mm", "Gonne-6"}},
+	{Operator: "Grim", Attacker: true, Primaries: []string{"552 Commando", "SG-CQB"}, Secondaries: []string{"P229", "Bailiff 410"}},
+	{Operator: "Brava", Attacker: true, Primaries: []string{"PARA-308", "CAMRS"}, Secondaries: []string{"Super Shorty", "USP40"}},
+	{Operator: "Ram", Attacker: true, Primaries: []string{"R4-C", "LMG-E"}, Secondaries: []string{"MK1 9mm", "ITA12S"}},
+	{Operator: "Deimos", Attacker: true, Primaries: []string{"AK-74M", "M590A1"}, Secondaries: []string{".44 Vendetta"}},
+
+	// defenders
+	{Operator: "Smoke", Primaries: []string{"FMG-9", "M590A1"}, Secondaries: []string{"P226 MK 25", "SMG-11"}},
+	{Operator: "Mute", Primaries: []string{"MP5K", "M590A1"}, Secondaries: []string{"P226 MK 25", "SMG-11"}},
+	{Operator: "Castle", Primaries: []string{"UMP45", "M1014"}, Secondaries: []string{"5.7 USG", "Super Shorty"}},
+	{Operator: "Pulse", Primaries: []string{"UMP45", "M1014"}, Secondaries: []string{"5.7 USG", "M45 MEUSOC"}},
+	{Operator: "Doc", Primaries: []string{"SG-CQB", "MP5", "P90"}, Secondaries: []string{"P9", "LFP586", "Bailiff 410"}},
+	{Operator: "Rook", Primaries: []string{"P90", "MP5", "SG-CQB"}, Secondaries: []string{"P9", "LFP586"}},
+	{Operator: "Kapkan", Primaries: []string{"9x19VSN", "SASG-12"}, Secondaries: []string{"PMM", "GSh-18"}},
+	{Operator: "Tachanka", Primaries: []string{"DP27", "9x19VSN"}, Secondaries: []string{"PMM", "GSh-18", "Bearing 9"}},
+	{Operator: "Jäger", Primaries: []string{"M870", "416-C CARBINE"}, Secondaries: []string{"P12"}},
+	{Operator: "Bandit", Primaries: []string{"MP7", "M870"}, Secondaries: []string{"P12"}},
+	{Operator: "Frost", Primaries: []string{"Super 90", "9mm C1"}, Secondaries: []string{"MK1 9mm", "ITA12S"}},
+	{Operator: "Valkyrie", Primaries: []string{"MPX", "SPAS-12"}, Secondaries: []string{"D-50"}},
+	{Operator: "Caveira", Primaries: []string{"M12", "SPAS-15"}, Secondaries: []string{"LUISON"}},
+	{Operator: "Echo", Primaries: []string{"SUPERNOVA", "MP5SD"}, Secondaries: []string{"P229", "Bearing 9"}},
+	{Operator: "Mira", Primaries: []string{"Vector .45 ACP", "ITA12L"}, Secondaries: []string{"USP40", "ITA12S"}},
+	{Operator: "Lesion", Primaries: []string{"SIX12 SD", "T-5 SMG"}, Secondaries: []string{"Q-929", "Super Shorty"}},
+	{Operator: "Ela", Primaries: []string{"Scorpion EVO 3 A1", "FO-12"}, Secondaries: []string{"RG15"}},
+	{Operator: "Vigil", Primaries: []string{"K1A", "BOSG.12.2"}, Secondaries: []string{"C75 Auto", "SMG-12"}},
+	{Operator: "Maestro", Primaries: []string{"ALDA 5.56", "ACS12"}, Secondaries: []string{"Bailiff 410", "Keratos .357"}},
+	{Operator: "Alibi", Primaries: []string{"Mx4 Storm", "ACS12"}, Secondaries: []string{"Keratos .357", "Bailiff 410"}},
+	{Operator: "Clash", Secondaries: []string{"SPSMG9", "P-10C", "Super Shorty"}},
+	{Operator: "Kaid", Primaries: []string{"AUG A3", "TCSG12"}, Secondaries: []string{".44 Mag Semi-Auto", "LFP586"}},
+	{Operator: "Mozzie", Primaries: []string{"Commando 9", "P10 RONI"}, Secondaries: []string{"SDP 9mm"}},
+	{Operator: "Warden", Primaries: []string{"M590A1", "MPX"}, Secondaries: []string{"P-10C", "SMG-12"}},
+	{Operator: "Goyo", Primaries: []string{"Vector .45 ACP", "TCSG12"}, Secondaries: []string{"P229"}},
+	{Operator: "Wamai", Primaries: []string{"AUG A2", "MP5K"}, Secondaries: []string{"Keratos .357", "P12"}},
+	{Operator: "Oryx", Primaries: []string{"T-5 SMG", "SPAS-12"}, Secondaries: []string{"Bailiff 410", "USP40"}},
+	{Operator: "Melusi", Primaries: []string{"MP5", "Super 90"}, Secondaries: []string{"RG15"}},
+	{Operator: "Aruni", Primaries: []string{"P10 RONI", "Mk 14 EBR"}, Secondaries: []string{"PRB92"}},
+	{Operator: "Thunderbird", Primaries: []string{"Spear .308", "SPAS-15"}, Secondaries: []string{"Bearing 9", "Q-929"}},
+	{Operator: "Thorn", Primaries: []string{"UZK50GI", "M870"}, Secondaries: []string{"1911 TACOPS", "C75 Auto"}},
+	{Operator: "Azami", Primaries: []string{"9x19VSN", "ACS12"}, Secondaries: []string{"D-50"}},
+	{Operator: "Solis", Primaries: []string{"P90", "ITA12L"}, Secondaries: []string{"SMG-11"}},
+	{Operator: "Fenrir", Primaries: []string{"MP7", "SASG-12"}, Secondaries: []string{"Bailiff 410", "5.7 USG"}},
+	{Operator: "Tubarão", Primaries: []string{"MPX", "AR-15.50"}, Secondaries: []string{"P226 MK 25"}},
+}
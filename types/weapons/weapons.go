@@ -0,0 +1,197 @@
+// Package weapons contains a static catalogue of weapons and operator loadouts,
+// allowing to relate weapon stats to the operators carrying the weapons.
+package weapons
+
+import (
+	"sort"
+
+	"github.com/stnokott/r6api/internal/keys"
+	"github.com/stnokott/r6api/types/operators"
+	"github.com/stnokott/r6api/types/stats"
+)
+
+// Weapon types as named in weapon stats responses.
+const (
+	AssaultRifle    = "Assault Rifle"
+	SubmachineGun   = "Submachine Gun"
+	Shotgun         = "Shotgun"
+	MarksmanRifle   = "Marksman Rifle"
+	LightMachineGun = "Light Machine Gun"
+	Handgun         = "Handgun"
+	MachinePistol   = "Machine Pistol"
+)
+
+type Slot string
+
+const (
+	Primary   Slot = "primary"
+	Secondary Slot = "secondary"
+)
+
+// Weapon is a single weapon of the catalogue.
+type Weapon struct {
+	Name      string
+	Type      string   // e.g. AssaultRifle
+	Slot      Slot     // slot the weapon is carried in
+	Operators []string // operators carrying the weapon, in alphabetical order
+}
+
+// Loadout contains the weapons an operator can choose from.
+type Loadout struct {
+	Operator    string
+	Attacker    bool
+	Primaries   []string
+	Secondaries []string
+}
+
+var catalogue = map[string]*Weapon{}
+
+func init() {
+	types := make(map[string]string, len(weaponTypes))
+	for name, weaponType := range weaponTypes {
+		types[keys.Normalize(name)] = weaponType
+	}
+
+	for _, loadout := range loadouts {
+		slots := []Slot{Primary, Secondary}
+		for i, names := range [][]string{loadout.Primaries, loadout.Secondaries} {
+			slot := slots[i]
+			for _, name := range names {
+				key := keys.Normalize(name)
+				w, ok := catalogue[key]
+				if !ok {
+					w = &Weapon{Name: name, Type: types[key], Slot: slot}
+					catalogue[key] = w
+				}
+				w.Operators = append(w.Operators, loadout.Operator)
+			}
+		}
+	}
+	for _, w := range catalogue {
+		sort.Strings(w.Operators)
+	}
+}
+
+// Lookup returns the weapon with the provided name (e.g. as contained in stats.WeaponNamesMap),
+// ignoring case, diacritics, whitespace and punctuation (e.g. "P10-RONI" for "P10 RONI").
+// Returns false if the weapon is not part of the catalogue.
+func Lookup(name string) (Weapon, bool) {
+	w, ok := catalogue[keys.Normalize(name)]
+	if !ok {
+		return Weapon{}, false
+	}
+	return copyWeapon(w), true
+}
+
+// All returns all weapons of the catalogue ordered by name.
+func All() []Weapon {
+	result := make([]Weapon, 0, len(catalogue))
+	for _, w := range catalogue {
+		result = append(result, copyWeapon(w))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Loadouts returns the loadouts of all operators.
+// Loadouts change over time, the catalogue is compiled by hand and covers the operators released up to Y9S1
+// without tracking later loadout changes.
+func Loadouts() []Loadout {
+	result := make([]Loadout, len(loadouts))
+	for i, l := range loadouts {
+		result[i] = Loadout{
+			Operator:    l.Operator,
+			Attacker:    l.Attacker,
+			Primaries:   append([]string(nil), l.Primaries...),
+			Secondaries: append([]string(nil), l.Secondaries...),
+		}
+	}
+	return result
+}
+
+// LoadoutOf returns the loadout of the operator with the provided name, which is resolved with operators.Lookup.
+// Returns false if the operator is not part of the catalogue.
+func LoadoutOf(operator string) (Loadout, bool) {
+	if o, ok := operators.Lookup(operator); ok {
+		operator = o.Name
+	}
+	key := keys.Normalize(operator)
+	for _, l := range Loadouts() {
+		if keys.Normalize(l.Operator) == key {
+			return l, true
+		}
+	}
+	return Loadout{}, false
+}
+
+func copyWeapon(w *Weapon) Weapon {
+	c := *w
+	c.Operators = append([]string(nil), w.Operators...)
+	return c
+}
+
+// LoadoutStats are the weapon stats of a single operator's loadout.
+type LoadoutStats struct {
+	Operator string
+	Weapons  stats.WeaponNamesMap // stats of the weapons of the loadout which were played, keyed by their name in the response
+	Total    stats.WeaponNamedStats
+}
+
+// JoinLoadouts relates the weapon stats of a team role to the loadouts of the operators carrying them,
+// returning the stats of every operator who carries at least one of the played weapons, ordered by operator name.
+// teamRole restricts the operators to attackers (stats.ATTACKER) or defenders (stats.DEFENDER), stats.ALL_ROLES includes both.
+//
+// Weapon stats are not broken down by operator, so the stats of a weapon shared by multiple operators count for all of them.
+// Weapons not contained in the catalogue are ignored.
+func JoinLoadouts(weapons *stats.WeaponTypes, teamRole stats.TeamRole) []LoadoutStats {
+	if weapons == nil {
+		return nil
+	}
+	played := stats.WeaponNamesMap{}
+	for _, typesMap := range []stats.WeaponTypesMap{weapons.PrimaryWeapons, weapons.SecondaryWeapons} {
+		for _, namesMap := range typesMap {
+			for name, s := range namesMap {
+				played[name] = s
+			}
+		}
+	}
+
+	var result []LoadoutStats
+	for _, loadout := range loadouts {
+		if (teamRole == stats.ATTACKER && !loadout.Attacker) || (teamRole == stats.DEFENDER && loadout.Attacker) {
+			continue
+		}
+		loadoutStats := LoadoutStats{Operator: loadout.Operator, Weapons: stats.WeaponNamesMap{}}
+		var all []stats.WeaponNamedStats
+		for _, name := range keys.Sorted(played) {
+			if !loadout.carries(name) {
+				continue
+			}
+			loadoutStats.Weapons[name] = played[name]
+			all = append(all, played[name])
+		}
+		if len(all) == 0 {
+			continue
+		}
+		loadoutStats.Total = stats.AggregateWeapons(all...)
+		result = append(result, loadoutStats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Operator < result[j].Operator
+	})
+	return result
+}
+
+func (l Loadout) carries(weapon string) bool {
+	key := keys.Normalize(weapon)
+	for _, names := range [][]string{l.Primaries, l.Secondaries} {
+		for _, name := range names {
+			if keys.Normalize(name) == key {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package weapons
+
+import (
+	"testing"
+
+	"github.com/stnokott/r6api/internal/cataloguetest"
+	"github.com/stnokott/r6api/internal/keys"
+	"github.com/stnokott/r6api/types/operators"
+	"github.com/stnokott/r6api/types/stats"
+)
+
+func TestCatalogue(t *testing.T) {
+	used := map[string]bool{}
+	for _, w := range All() {
+		if w.Type == "" {
+			t.Errorf("weapon %s has no type", w.Name)
+		}
+		if len(w.Operators) == 0 {
+			t.Errorf("weapon %s has no operators", w.Name)
+		}
+		used[keys.Normalize(w.Name)] = true
+	}
+	for name := range weaponTypes {
+		if !used[keys.Normalize(name)] {
+			t.Errorf("weapon %s is not carried by any operator", name)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	cataloguetest.RunLookups(t, func(name string) (string, bool) {
+		w, ok := Lookup(name)
+		return w.Name, ok
+	},
+		cataloguetest.LookupCase{Name: "P10 RONI", Want: "P10 RONI"},
+		cataloguetest.LookupCase{Name: "p10-roni", Want: "P10 RONI"},
+		cataloguetest.LookupCase{Name: "Unknown Gun"},
+	)
+
+	w, _ := Lookup("p10-roni")
+	if w.Type != SubmachineGun || w.Slot != Primary {
+		t.Errorf("unexpected weapon %+v", w)
+	}
+	if want := []string{"Aruni", "Mozzie"}; len(w.Operators) != len(want) || w.Operators[0] != want[0] || w.Operators[1] != want[1] {
+		t.Errorf("want operators %v, got %v", want, w.Operators)
+	}
+
+	w.Operators[0] = "modified"
+	if w, _ = Lookup("P10 RONI"); w.Operators[0] != "Aruni" {
+		t.Error("modifying result should not affect catalogue")
+	}
+}
+
+func TestJoinLoadouts(t *testing.T) {
+	weapon := cataloguetest.Weapon
+	weapons := &stats.WeaponTypes{
+		PrimaryWeapons: stats.WeaponTypesMap{
+			AssaultRifle: {"R4-C": weapon(40, 45), "G36C": weapon(10, 10)},
+			Shotgun:      {"M590A1": weapon(5, 5)},
+		},
+		SecondaryWeapons: stats.WeaponTypesMap{
+			Handgun: {"5.7 USG": weapon(3, 20)},
+		},
+	}
+
+	joined := JoinLoadouts(weapons, stats.ATTACKER)
+	byOperator := map[string]LoadoutStats{}
+	for _, l := range joined {
+		byOperator[l.Operator] = l
+	}
+	ash, ok := byOperator["Ash"]
+	if !ok {
+		t.Fatal("missing operator Ash")
+	}
+	if len(ash.Weapons) != 3 || ash.Total.Kills != 53 || ash.Total.RoundsPlayed != 75 {
+		t.Errorf("unexpected stats of Ash: %+v", ash)
+	}
+	if _, ok = byOperator["Smoke"]; ok {
+		t.Error("defenders should be excluded for team role Attacker")
+	}
+	if sledge := byOperator["Sledge"]; sledge.Total.Kills != 5 {
+		t.Errorf("want 5 kills for Sledge, got %d", sledge.Total.Kills)
+	}
+	for i := 1; i < len(joined); i++ {
+		if joined[i-1].Operator > joined[i].Operator {
+			t.Fatal("result should be ordered by operator")
+		}
+	}
+
+	defenders := JoinLoadouts(weapons, stats.DEFENDER)
+	if len(defenders) == 0 || defenders[0].Operator == "Ash" {
+		t.Errorf("unexpected defenders %+v", defenders)
+	}
+}
+
+func TestLoadoutOf(t *testing.T) {
+	l, ok := LoadoutOf("jager")
+	if !ok || l.Operator != "Jäger" || l.Attacker {
+		t.Errorf("unexpected loadout %+v", l)
+	}
+	if _, ok = LoadoutOf("Recruit"); ok {
+		t.Error("unknown operator should not be found")
+	}
+}
+
+func TestLoadoutsMatchOperators(t *testing.T) {
+	withLoadout := map[string]bool{}
+	for _, l := range Loadouts() {
+		o, ok := operators.Lookup(l.Operator)
+		if !ok {
+			t.Errorf("operator %s missing from operator catalogue", l.Operator)
+			continue
+		}
+		if (o.Side == operators.Attacker) != l.Attacker {
+			t.Errorf("side of %s differs from operator catalogue", l.Operator)
+		}
+		withLoadout[o.Name] = true
+	}
+	for _, o := range operators.All() {
+		if !withLoadout[o.Name] {
+			t.Errorf("operator %s has no loadout", o.Name)
+		}
+	}
+}